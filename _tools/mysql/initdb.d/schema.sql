@@ -3,37 +3,168 @@ CREATE TABLE `user` (
   -- 0 はアプリケーション側のint型のゼロ値であり、バリデーションで弾く実装を行う
   `id` bigint NOT NULL AUTO_INCREMENT,
   `name` varchar(255) DEFAULT NULL,
+  -- 作成時に発行したトークンのハッシュ (認証には user_session を使う)
+  -- 平文で保存されている古いトークンは初回の認証時にハッシュへ置き換える
   `token` varchar(255) DEFAULT NULL,
   `leader_card_id` int DEFAULT NULL,
+  -- フレンド検索用の公開コード (id や token を公開しないため)
+  `friend_code` varchar(16) DEFAULT NULL,
   `created_at` datetime DEFAULT NULL,
   `updated_at` datetime DEFAULT NULL,
   PRIMARY KEY (`id`),
-  UNIQUE KEY `token` (`token`)
+  UNIQUE KEY `token` (`token`),
+  UNIQUE KEY `friend_code` (`friend_code`),
+  -- 管理 API のユーザー検索 (前方一致) 用
+  KEY `name` (`name`)
 ) Engine=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='ユーザー';
 
+-- ログインセッション
+-- 認証トークンは expires_at まで有効 (revoked_at が設定されたものは無効)
+-- token には server-side pepper を鍵とした HMAC ('hmac-sha256:<hex>') のみ保存する
+CREATE TABLE `user_session` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `user_id` bigint NOT NULL,
+  `token` varchar(255) NOT NULL,
+  `issued_at` datetime NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `revoked_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `token` (`token`),
+  KEY `user_id` (`user_id`)
+);
+
+-- 利用停止 (BAN)
+-- starts_at <= 現在時刻 < ends_at (NULL は無期限) かつ lifted_at が NULL のものが有効
+CREATE TABLE `user_ban` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `user_id` bigint NOT NULL,
+  `reason` varchar(255) NOT NULL DEFAULT '',
+  `starts_at` datetime NOT NULL,
+  `ends_at` datetime DEFAULT NULL,
+  -- 管理 API で解除した時刻
+  `lifted_at` datetime DEFAULT NULL,
+  -- BAN を設定した管理者 (ADMIN_CREDENTIALS の名前)
+  `admin_name` varchar(64) NOT NULL DEFAULT '',
+  `created_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `user_id` (`user_id`)
+);
+
+-- 引き継ぎ (機種変更) 用のコード
+-- ユーザーごとに 1 つ (再発行すると上書きする)、引き継ぎに成功すると削除する
+CREATE TABLE `user_transfer` (
+  `user_id` bigint NOT NULL,
+  `transfer_id` varchar(16) NOT NULL,
+  -- ユーザーが設定したパスワードの bcrypt ハッシュ
+  `password_hash` varchar(255) NOT NULL,
+  `expires_at` datetime NOT NULL,
+  -- パスワードを連続で間違えた回数 (ロック時に 0 に戻す)
+  `failed_count` int NOT NULL DEFAULT 0,
+  `locked_until` datetime DEFAULT NULL,
+  `created_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`user_id`),
+  UNIQUE KEY `transfer_id` (`transfer_id`)
+);
+
+-- フレンド関係
+-- user_id -> friend_user_id の向きで 1 行
+-- - フレンド申請中: (申請者, 相手, Requested) の 1 行
+-- - フレンド: (A, B, Accepted) と (B, A, Accepted) の 2 行
+CREATE TABLE `friend` (
+  `user_id` bigint NOT NULL,
+  `friend_user_id` bigint NOT NULL,
+  `status` int NOT NULL DEFAULT 1,
+  `created_at` datetime DEFAULT NULL,
+  `updated_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`user_id`, `friend_user_id`),
+  KEY `friend_user_id` (`friend_user_id`)
+);
+
+-- ブロック
+-- user_id が blocked_user_id をブロックしている
+CREATE TABLE `user_block` (
+  `user_id` bigint NOT NULL,
+  `blocked_user_id` bigint NOT NULL,
+  `created_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`user_id`, `blocked_user_id`),
+  KEY `blocked_user_id` (`blocked_user_id`)
+);
+
 CREATE TABLE `room` (
   `id` bigint NOT NULL AUTO_INCREMENT,
   -- 楽曲ID
   `live_id` bigint NOT NULL,
   `host_user_id` bigint NOT NULL,
   `status` int NOT NULL DEFAULT 1,
+  -- 現在のラウンド (1 スタート)
+  `round` int NOT NULL DEFAULT 1,
+  -- `/room/rematch` で作成されたルーム (0 は未作成)
+  `rematch_room_id` bigint NOT NULL DEFAULT 0,
+  -- 全員が同時にライブを開始する時刻 (Waiting 中は NULL)
+  `start_at` datetime(3) DEFAULT NULL,
   `created_at` datetime DEFAULT NULL,
   `updated_at` datetime DEFAULT NULL,
   PRIMARY KEY (`id`)
 );
 
+-- ルームで演奏する楽曲リスト (セットリスト)
+-- セットリストを指定せずに作成されたルームには行が存在しない
+CREATE TABLE `room_setlist` (
+  `room_id` bigint NOT NULL,
+  `round` int NOT NULL,
+  `live_id` bigint NOT NULL,
+  PRIMARY KEY (`room_id`, `round`)
+);
+
 CREATE TABLE `room_user` (
   `room_id` bigint NOT NULL AUTO_INCREMENT,
   `user_id` bigint NOT NULL,
   `live_difficulty` int NOT NULL,
   `status` int NOT NULL DEFAULT 1,
+  -- 再戦を希望しているかどうか
+  `rematch` tinyint(1) NOT NULL DEFAULT 0,
+  -- 1: Player, 2: Spectator
+  `role` int NOT NULL DEFAULT 1,
   PRIMARY KEY (`room_id`, `user_id`)
 );
 
 
--- TODO: `room_user` と primary key の取り方を同じにしているが、他に良い方法はあるだろうか？
+-- ルームへの招待
+-- 同じルームへの再招待は既存の行を更新する
+CREATE TABLE `room_invitation` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `room_id` bigint NOT NULL,
+  `inviter_user_id` bigint NOT NULL,
+  `invitee_user_id` bigint NOT NULL,
+  -- 1: Pending, 2: Accepted, 3: Declined
+  `status` int NOT NULL DEFAULT 1,
+  `expires_at` datetime NOT NULL,
+  `created_at` datetime DEFAULT NULL,
+  `updated_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `room_id_invitee_user_id` (`room_id`, `invitee_user_id`),
+  KEY `invitee_user_id_expires_at` (`invitee_user_id`, `expires_at`)
+);
+
+-- ルーム内チャット (テキストまたはスタンプ)
+-- ルームの解散時に削除する
+CREATE TABLE `room_chat` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `room_id` bigint NOT NULL,
+  `user_id` bigint NOT NULL,
+  `message` varchar(255) NOT NULL DEFAULT '',
+  -- 0 はテキストメッセージ
+  `stamp_id` int NOT NULL DEFAULT 0,
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `room_id_id` (`room_id`, `id`),
+  KEY `room_id_user_id_created_at` (`room_id`, `user_id`, `created_at`)
+);
+
+-- 同じメンバーで複数回ライブを行うため、ラウンド毎にスコアを保持する
 CREATE TABLE `score` (
   `room_id` bigint NOT NULL,
+  `round` int NOT NULL DEFAULT 1,
   `user_id` bigint NOT NULL,
   `score` int NOT NULL,
   `judge_perfect` int NOT NULL,
@@ -41,5 +172,63 @@ CREATE TABLE `score` (
   `judge_good` int NOT NULL,
   `judge_bad` int NOT NULL,
   `judge_miss` int NOT NULL,
-  PRIMARY KEY (`room_id`, `user_id`)
+  PRIMARY KEY (`room_id`, `round`, `user_id`)
+);
+
+-- 監査ログ (追記のみ、UPDATE / DELETE しない)
+-- ユーザーの操作と管理 API の操作 (参照のみの操作を含む) を記録する
+CREATE TABLE `audit_log` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  -- 管理者の操作の場合は 0
+  `actor_user_id` bigint NOT NULL DEFAULT 0,
+  -- ADMIN_CREDENTIALS の名前 (ユーザーの操作の場合は空文字列)
+  `actor_admin_name` varchar(64) NOT NULL DEFAULT '',
+  `action` varchar(64) NOT NULL,
+  -- 対象が無い場合は 0
+  `target_user_id` bigint NOT NULL DEFAULT 0,
+  `target_room_id` bigint NOT NULL DEFAULT 0,
+  -- X-Request-Id
+  `request_id` varchar(64) NOT NULL DEFAULT '',
+  -- 操作の引数など
+  `detail` varchar(1024) NOT NULL DEFAULT '',
+  `created_at` datetime(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `actor_user_id` (`actor_user_id`),
+  KEY `target_user_id` (`target_user_id`),
+  KEY `target_room_id` (`target_room_id`)
+);
+
+-- Idempotency-Key ヘッダー付きの POST リクエストの最初のレスポンス
+-- 同じキーでの再送には expires_at まで保存したレスポンスを返す
+CREATE TABLE `idempotency_key` (
+  -- 認証前のリクエスト (`/user/create` など) は 0
+  `user_id` bigint NOT NULL,
+  -- Idempotency-Key の HMAC (認証前はキーとリクエストの SHA-256 の組の HMAC)
+  `idempotency_key` char(64) NOT NULL,
+  -- メソッド・パス・ボディの SHA-256 (異なるリクエストでのキーの再利用を拒否する)
+  `request_hash` char(64) NOT NULL,
+  -- 0 は処理中
+  `status_code` int NOT NULL DEFAULT 0,
+  -- Idempotency-Key から導出した鍵で暗号化したレスポンス (AES-GCM)
+  `response_body` mediumblob,
+  -- 処理中のリクエストが定期的に延長する (過ぎた場合はサーバーの停止などで残ったものとみなす)
+  `locked_until` datetime NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `created_at` datetime DEFAULT NULL,
+  PRIMARY KEY (`user_id`, `idempotency_key`),
+  KEY `expires_at` (`expires_at`)
+);
+
+-- メンテナンスモード (id = 1 の 1 行のみ、行が無い場合はメンテナンス中ではない)
+CREATE TABLE `maintenance` (
+  `id` tinyint NOT NULL,
+  `enabled` tinyint(1) NOT NULL DEFAULT 0,
+  -- ユーザーに表示するメッセージ
+  `message` varchar(255) NOT NULL DEFAULT '',
+  -- 終了予定時刻 (表示のみ、NULL は未定)
+  `ends_at` datetime DEFAULT NULL,
+  -- 最後に変更した管理者
+  `admin_name` varchar(64) NOT NULL DEFAULT '',
+  `updated_at` datetime NOT NULL,
+  PRIMARY KEY (`id`)
 );
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+var ErrInvalidAdminCredential = errors.New("invalid admin credential")
+
+// 管理 API の認証 (ユーザーのトークンとは別の資格情報を使う)
+type AdminAuthorizer struct {
+	// 管理者の名前 -> トークン
+	Credentials map[string]string
+}
+
+// *http.Request型から管理者の名前を context に書き込む
+func (au *AdminAuthorizer) FillContext(r *http.Request) (*http.Request, error) {
+	token, err := ExtractBearerToken(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// 一致するかどうかに関わらず全ての資格情報と比較する
+	var adminName string
+	for name, credential := range au.Credentials {
+		if credential == "" {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(credential)) == 1 {
+			adminName = name
+		}
+	}
+	if adminName == "" {
+		return nil, ErrInvalidAdminCredential
+	}
+
+	return r.Clone(service.SetAdminName(r.Context(), adminName)), nil
+}
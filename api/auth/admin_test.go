@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+func TestAdminAuthorizer(t *testing.T) {
+	t.Parallel()
+
+	credentials := map[string]string{
+		"alice": "alice-token",
+		"bob":   "bob-token",
+		// 空の資格情報では認証できない
+		"empty": "",
+	}
+
+	tests := map[string]struct {
+		header    string
+		wantName  string
+		wantIsErr bool
+		wantErr   error
+	}{
+		"ok_alice": {
+			header:   "Bearer alice-token",
+			wantName: "alice",
+		},
+		"ok_bob": {
+			header:   "Bearer bob-token",
+			wantName: "bob",
+		},
+		"ng_unknown_token": {
+			header:    "Bearer unknown-token",
+			wantIsErr: true,
+			wantErr:   ErrInvalidAdminCredential,
+		},
+		"ng_empty_token": {
+			header:    "Bearer ",
+			wantIsErr: true,
+			wantErr:   ErrInvalidAdminCredential,
+		},
+		"ng_no_header": {
+			wantIsErr: true,
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "/admin/user", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+
+			sut := &AdminAuthorizer{Credentials: credentials}
+			got, err := sut.FillContext(r)
+			if tt.wantIsErr {
+				if err == nil {
+					t.Fatal("expected error, but got nil")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("error is not match: want %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			name, ok := service.GetAdminName(got.Context())
+			if !ok {
+				t.Fatal("admin name is not set in context")
+			}
+			if diff := cmp.Diff(tt.wantName, name); diff != "" {
+				t.Errorf("admin name is not match (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,43 +2,159 @@ package auth
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/clock"
 	"github.com/pollenjp/gameserver-go/api/entity"
 	"github.com/pollenjp/gameserver-go/api/service"
+	"github.com/pollenjp/gameserver-go/api/store"
 )
 
+var (
+	ErrTokenExpired = errors.New("token is expired")
+	ErrTokenRevoked = errors.New("token is revoked")
+)
+
+// 利用停止中のユーザー
+type ErrUserBanned struct {
+	Ban *entity.UserBan
+}
+
+func (e *ErrUserBanned) Error() string {
+	return fmt.Sprintf("user is banned: %s", e.Ban.Reason)
+}
+
 //go:generate go run github.com/matryer/moq -out auth_moq_test.go . AuthRepository
 type AuthRepository interface {
-	GetUserFromToken(ctx context.Context, db service.Queryer, userToken entity.UserTokenType) (*entity.User, error)
+	GetUserSessionFromToken(ctx context.Context, db service.Queryer, token entity.UserTokenType) (*entity.UserSession, error)
+	MigrateLegacyUserToken(ctx context.Context, db service.QueryerAndExecer, token entity.UserTokenType) (*entity.UserSession, error)
+	GetActiveUserBan(ctx context.Context, db service.Queryer, userId entity.UserId, now time.Time) (*entity.UserBan, error)
 }
 
-func NewAuthorizer(db service.Queryer, repo AuthRepository) *Authorizer {
+func NewAuthorizer(db service.BeginnerAndQueryer, repo AuthRepository, clocker clock.Clocker) *Authorizer {
 	return &Authorizer{
-		DB:   db,
-		Repo: repo,
+		DB:      db,
+		Repo:    repo,
+		Clocker: clocker,
 	}
 }
 
 type Authorizer struct {
-	DB   service.Queryer
-	Repo AuthRepository
+	DB      service.BeginnerAndQueryer
+	Repo    AuthRepository
+	Clocker clock.Clocker
+	// nil の場合は JWT を受け付けない (opaque なトークンのみ)
+	JWT *JWT
+	// nil の場合は毎回 DB を参照する
+	Cache *store.SessionCache
 }
 
 // *http.Request型から認証情報を context に書き込む
+//
+// 期限切れ・無効化済みのトークンの場合は ErrTokenExpired / ErrTokenRevoked を返す
+// 利用停止中のユーザーの場合は *ErrUserBanned を返す
+//
+// JWT が有効な場合は JWT 形式のトークンを DB を参照せずに検証する (セッション Id は設定されない)
+// JWT は BAN を確認しないが、発行 (`/user/login`) 時に確認するため最大 AccessTokenLifetime で使えなくなる
 func (au *Authorizer) FillContext(r *http.Request) (*http.Request, error) {
 	token, err := ExtractBearerToken(r)
 	if err != nil {
 		return nil, err
 	}
 
-	u, err := au.Repo.GetUserFromToken(r.Context(), au.DB, token)
+	if au.JWT != nil && looksLikeJWT(token) {
+		userId, err := au.JWT.Verify(token)
+		if err != nil {
+			return nil, err
+		}
+		return r.Clone(service.SetUserId(r.Context(), userId)), nil
+	}
+
+	session, err := au.getSession(r.Context(), token)
 	if err != nil {
 		return nil, err
 	}
+	if session.IsRevoked() {
+		return nil, ErrTokenRevoked
+	}
+	if session.IsExpired(au.Clocker.Now()) {
+		return nil, ErrTokenExpired
+	}
 
-	ctx := service.SetUserId(r.Context(), u.Id)
+	ctx := service.SetUserId(r.Context(), session.UserId)
+	ctx = service.SetSessionId(ctx, session.Id)
 
 	clone := r.Clone(ctx)
 	return clone, nil
 }
+
+// 同じトークンでの同時の認証で二重に移行しないように、トランザクション内で移行する
+func (au *Authorizer) migrateLegacyToken(ctx context.Context, token entity.UserTokenType) (*entity.UserSession, error) {
+	// helper functions
+	fail := func(err error) (*entity.UserSession, error) {
+		return nil, fmt.Errorf("migrateLegacyToken: %w", err)
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) (*entity.UserSession, error) {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	tx, err := au.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+	session, err := au.Repo.MigrateLegacyUserToken(ctx, tx, token)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+	return session, nil
+}
+
+func (au *Authorizer) getSession(ctx context.Context, token entity.UserTokenType) (*entity.UserSession, error) {
+	// 読み込み中に無効化されたセッションをキャッシュしないように、DB を読む前に世代を取得する
+	var generation uint64
+	if au.Cache != nil {
+		if session, ok := au.Cache.Get(token); ok {
+			return session, nil
+		}
+		generation = au.Cache.Generation()
+	}
+
+	session, err := au.Repo.GetUserSessionFromToken(ctx, au.DB, token)
+	if errors.Is(err, sql.ErrNoRows) {
+		// 平文で保存されていた頃のトークンは初回の認証時にハッシュへ移行する
+		session, err = au.migrateLegacyToken(ctx, token)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// 無効化済みのセッションは再び有効になることがないため、キャッシュせずに毎回 DB で確認する
+	if session.IsRevoked() {
+		return session, nil
+	}
+
+	// BAN はキャッシュしたセッションの有効期間中は確認しない (BAN した時にキャッシュを削除する)
+	now := au.Clocker.Now()
+	ban, err := au.Repo.GetActiveUserBan(ctx, au.DB, session.UserId, now)
+	if err != nil {
+		return nil, err
+	}
+	if ban != nil && ban.IsActive(now) {
+		return nil, &ErrUserBanned{Ban: ban}
+	}
+
+	if au.Cache != nil {
+		au.Cache.Set(token, session, generation)
+	}
+	return session, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/pollenjp/gameserver-go/api/entity"
 	"github.com/pollenjp/gameserver-go/api/service"
 	"sync"
+	"time"
 )
 
 // Ensure, that AuthRepositoryMock does implement AuthRepository.
@@ -20,8 +21,14 @@ var _ AuthRepository = &AuthRepositoryMock{}
 //
 //		// make and configure a mocked AuthRepository
 //		mockedAuthRepository := &AuthRepositoryMock{
-//			GetUserFromTokenFunc: func(ctx context.Context, db service.Queryer, userToken entity.UserTokenType) (*entity.User, error) {
-//				panic("mock out the GetUserFromToken method")
+//			GetActiveUserBanFunc: func(ctx context.Context, db service.Queryer, userId entity.UserId, now time.Time) (*entity.UserBan, error) {
+//				panic("mock out the GetActiveUserBan method")
+//			},
+//			GetUserSessionFromTokenFunc: func(ctx context.Context, db service.Queryer, token entity.UserTokenType) (*entity.UserSession, error) {
+//				panic("mock out the GetUserSessionFromToken method")
+//			},
+//			MigrateLegacyUserTokenFunc: func(ctx context.Context, db service.QueryerAndExecer, token entity.UserTokenType) (*entity.UserSession, error) {
+//				panic("mock out the MigrateLegacyUserToken method")
 //			},
 //		}
 //
@@ -30,60 +37,172 @@ var _ AuthRepository = &AuthRepositoryMock{}
 //
 //	}
 type AuthRepositoryMock struct {
-	// GetUserFromTokenFunc mocks the GetUserFromToken method.
-	GetUserFromTokenFunc func(ctx context.Context, db service.Queryer, userToken entity.UserTokenType) (*entity.User, error)
+	// GetActiveUserBanFunc mocks the GetActiveUserBan method.
+	GetActiveUserBanFunc func(ctx context.Context, db service.Queryer, userId entity.UserId, now time.Time) (*entity.UserBan, error)
+
+	// GetUserSessionFromTokenFunc mocks the GetUserSessionFromToken method.
+	GetUserSessionFromTokenFunc func(ctx context.Context, db service.Queryer, token entity.UserTokenType) (*entity.UserSession, error)
+
+	// MigrateLegacyUserTokenFunc mocks the MigrateLegacyUserToken method.
+	MigrateLegacyUserTokenFunc func(ctx context.Context, db service.QueryerAndExecer, token entity.UserTokenType) (*entity.UserSession, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
-		// GetUserFromToken holds details about calls to the GetUserFromToken method.
-		GetUserFromToken []struct {
+		// GetActiveUserBan holds details about calls to the GetActiveUserBan method.
+		GetActiveUserBan []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 			// Db is the db argument value.
 			Db service.Queryer
-			// UserToken is the userToken argument value.
-			UserToken entity.UserTokenType
+			// UserId is the userId argument value.
+			UserId entity.UserId
+			// Now is the now argument value.
+			Now time.Time
 		}
+		// GetUserSessionFromToken holds details about calls to the GetUserSessionFromToken method.
+		GetUserSessionFromToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db service.Queryer
+			// Token is the token argument value.
+			Token entity.UserTokenType
+		}
+		// MigrateLegacyUserToken holds details about calls to the MigrateLegacyUserToken method.
+		MigrateLegacyUserToken []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Db is the db argument value.
+			Db service.QueryerAndExecer
+			// Token is the token argument value.
+			Token entity.UserTokenType
+		}
+	}
+	lockGetActiveUserBan        sync.RWMutex
+	lockGetUserSessionFromToken sync.RWMutex
+	lockMigrateLegacyUserToken  sync.RWMutex
+}
+
+// GetActiveUserBan calls GetActiveUserBanFunc.
+func (mock *AuthRepositoryMock) GetActiveUserBan(ctx context.Context, db service.Queryer, userId entity.UserId, now time.Time) (*entity.UserBan, error) {
+	if mock.GetActiveUserBanFunc == nil {
+		panic("AuthRepositoryMock.GetActiveUserBanFunc: method is nil but AuthRepository.GetActiveUserBan was just called")
 	}
-	lockGetUserFromToken sync.RWMutex
+	callInfo := struct {
+		Ctx    context.Context
+		Db     service.Queryer
+		UserId entity.UserId
+		Now    time.Time
+	}{
+		Ctx:    ctx,
+		Db:     db,
+		UserId: userId,
+		Now:    now,
+	}
+	mock.lockGetActiveUserBan.Lock()
+	mock.calls.GetActiveUserBan = append(mock.calls.GetActiveUserBan, callInfo)
+	mock.lockGetActiveUserBan.Unlock()
+	return mock.GetActiveUserBanFunc(ctx, db, userId, now)
+}
+
+// GetActiveUserBanCalls gets all the calls that were made to GetActiveUserBan.
+// Check the length with:
+//
+//	len(mockedAuthRepository.GetActiveUserBanCalls())
+func (mock *AuthRepositoryMock) GetActiveUserBanCalls() []struct {
+	Ctx    context.Context
+	Db     service.Queryer
+	UserId entity.UserId
+	Now    time.Time
+} {
+	var calls []struct {
+		Ctx    context.Context
+		Db     service.Queryer
+		UserId entity.UserId
+		Now    time.Time
+	}
+	mock.lockGetActiveUserBan.RLock()
+	calls = mock.calls.GetActiveUserBan
+	mock.lockGetActiveUserBan.RUnlock()
+	return calls
+}
+
+// GetUserSessionFromToken calls GetUserSessionFromTokenFunc.
+func (mock *AuthRepositoryMock) GetUserSessionFromToken(ctx context.Context, db service.Queryer, token entity.UserTokenType) (*entity.UserSession, error) {
+	if mock.GetUserSessionFromTokenFunc == nil {
+		panic("AuthRepositoryMock.GetUserSessionFromTokenFunc: method is nil but AuthRepository.GetUserSessionFromToken was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Db    service.Queryer
+		Token entity.UserTokenType
+	}{
+		Ctx:   ctx,
+		Db:    db,
+		Token: token,
+	}
+	mock.lockGetUserSessionFromToken.Lock()
+	mock.calls.GetUserSessionFromToken = append(mock.calls.GetUserSessionFromToken, callInfo)
+	mock.lockGetUserSessionFromToken.Unlock()
+	return mock.GetUserSessionFromTokenFunc(ctx, db, token)
+}
+
+// GetUserSessionFromTokenCalls gets all the calls that were made to GetUserSessionFromToken.
+// Check the length with:
+//
+//	len(mockedAuthRepository.GetUserSessionFromTokenCalls())
+func (mock *AuthRepositoryMock) GetUserSessionFromTokenCalls() []struct {
+	Ctx   context.Context
+	Db    service.Queryer
+	Token entity.UserTokenType
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Db    service.Queryer
+		Token entity.UserTokenType
+	}
+	mock.lockGetUserSessionFromToken.RLock()
+	calls = mock.calls.GetUserSessionFromToken
+	mock.lockGetUserSessionFromToken.RUnlock()
+	return calls
 }
 
-// GetUserFromToken calls GetUserFromTokenFunc.
-func (mock *AuthRepositoryMock) GetUserFromToken(ctx context.Context, db service.Queryer, userToken entity.UserTokenType) (*entity.User, error) {
-	if mock.GetUserFromTokenFunc == nil {
-		panic("AuthRepositoryMock.GetUserFromTokenFunc: method is nil but AuthRepository.GetUserFromToken was just called")
+// MigrateLegacyUserToken calls MigrateLegacyUserTokenFunc.
+func (mock *AuthRepositoryMock) MigrateLegacyUserToken(ctx context.Context, db service.QueryerAndExecer, token entity.UserTokenType) (*entity.UserSession, error) {
+	if mock.MigrateLegacyUserTokenFunc == nil {
+		panic("AuthRepositoryMock.MigrateLegacyUserTokenFunc: method is nil but AuthRepository.MigrateLegacyUserToken was just called")
 	}
 	callInfo := struct {
-		Ctx       context.Context
-		Db        service.Queryer
-		UserToken entity.UserTokenType
+		Ctx   context.Context
+		Db    service.QueryerAndExecer
+		Token entity.UserTokenType
 	}{
-		Ctx:       ctx,
-		Db:        db,
-		UserToken: userToken,
+		Ctx:   ctx,
+		Db:    db,
+		Token: token,
 	}
-	mock.lockGetUserFromToken.Lock()
-	mock.calls.GetUserFromToken = append(mock.calls.GetUserFromToken, callInfo)
-	mock.lockGetUserFromToken.Unlock()
-	return mock.GetUserFromTokenFunc(ctx, db, userToken)
+	mock.lockMigrateLegacyUserToken.Lock()
+	mock.calls.MigrateLegacyUserToken = append(mock.calls.MigrateLegacyUserToken, callInfo)
+	mock.lockMigrateLegacyUserToken.Unlock()
+	return mock.MigrateLegacyUserTokenFunc(ctx, db, token)
 }
 
-// GetUserFromTokenCalls gets all the calls that were made to GetUserFromToken.
+// MigrateLegacyUserTokenCalls gets all the calls that were made to MigrateLegacyUserToken.
 // Check the length with:
 //
-//	len(mockedAuthRepository.GetUserFromTokenCalls())
-func (mock *AuthRepositoryMock) GetUserFromTokenCalls() []struct {
-	Ctx       context.Context
-	Db        service.Queryer
-	UserToken entity.UserTokenType
+//	len(mockedAuthRepository.MigrateLegacyUserTokenCalls())
+func (mock *AuthRepositoryMock) MigrateLegacyUserTokenCalls() []struct {
+	Ctx   context.Context
+	Db    service.QueryerAndExecer
+	Token entity.UserTokenType
 } {
 	var calls []struct {
-		Ctx       context.Context
-		Db        service.Queryer
-		UserToken entity.UserTokenType
+		Ctx   context.Context
+		Db    service.QueryerAndExecer
+		Token entity.UserTokenType
 	}
-	mock.lockGetUserFromToken.RLock()
-	calls = mock.calls.GetUserFromToken
-	mock.lockGetUserFromToken.RUnlock()
+	mock.lockMigrateLegacyUserToken.RLock()
+	calls = mock.calls.MigrateLegacyUserToken
+	mock.lockMigrateLegacyUserToken.RUnlock()
 	return calls
 }
@@ -2,17 +2,21 @@ package auth
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 	"github.com/pollenjp/gameserver-go/api/clock"
 	"github.com/pollenjp/gameserver-go/api/entity"
 	"github.com/pollenjp/gameserver-go/api/service"
+	"github.com/pollenjp/gameserver-go/api/store"
+	"github.com/pollenjp/gameserver-go/api/testutil"
 )
 
 func TestAuthorizer(t *testing.T) {
@@ -22,18 +26,73 @@ func TestAuthorizer(t *testing.T) {
 		errMsg string
 	}
 
+	c := clock.FixedClocker{}
+	revokedAt := c.Now().Add(-time.Minute)
 	token := entity.UserTokenType(uuid.NewString())
 	tests := map[string]struct {
-		isOk   bool
-		header http.Header
-		want   *want
+		isOk bool
+		// 平文で保存されていた頃のトークン
+		legacy    bool
+		header    http.Header
+		expiresAt time.Time
+		revokedAt *time.Time
+		// 有効な BAN (無い場合は nil)
+		ban  *entity.UserBan
+		want *want
 	}{
 		"ok": {
 			isOk: true,
 			header: http.Header{
 				"Authorization": []string{fmt.Sprintf("Bearer %s", token)},
 			},
-			want: nil,
+			expiresAt: c.Now().Add(time.Hour),
+			want:      nil,
+		},
+		"ok_legacy": {
+			isOk:   true,
+			legacy: true,
+			header: http.Header{
+				"Authorization": []string{fmt.Sprintf("Bearer %s", token)},
+			},
+			expiresAt: c.Now().Add(time.Hour),
+			want:      nil,
+		},
+		"ng_expired": {
+			isOk: true,
+			header: http.Header{
+				"Authorization": []string{fmt.Sprintf("Bearer %s", token)},
+			},
+			expiresAt: c.Now(),
+			want: &want{
+				errMsg: ErrTokenExpired.Error(),
+			},
+		},
+		"ng_revoked": {
+			isOk: true,
+			header: http.Header{
+				"Authorization": []string{fmt.Sprintf("Bearer %s", token)},
+			},
+			expiresAt: c.Now().Add(time.Hour),
+			revokedAt: &revokedAt,
+			want: &want{
+				errMsg: ErrTokenRevoked.Error(),
+			},
+		},
+		"ng_banned": {
+			isOk: true,
+			header: http.Header{
+				"Authorization": []string{fmt.Sprintf("Bearer %s", token)},
+			},
+			expiresAt: c.Now().Add(time.Hour),
+			ban: &entity.UserBan{
+				Id:       1,
+				UserId:   1,
+				Reason:   "cheating",
+				StartsAt: c.Now().Add(-time.Hour),
+			},
+			want: &want{
+				errMsg: "user is banned: cheating",
+			},
 		},
 		"ng_authorization_header": {
 			isOk:   false,
@@ -55,7 +114,6 @@ func TestAuthorizer(t *testing.T) {
 
 	for n, tt := range tests {
 		tt := tt
-		c := clock.FixedClocker{}
 
 		t.Run(n, func(t *testing.T) {
 			t.Parallel()
@@ -78,11 +136,7 @@ func TestAuthorizer(t *testing.T) {
 			}
 
 			moq := &AuthRepositoryMock{}
-			moq.GetUserFromTokenFunc = func(
-				_ context.Context,
-				_ service.Queryer,
-				token entity.UserTokenType,
-			) (*entity.User, error) {
+			getSession := func(token entity.UserTokenType) (*entity.UserSession, error) {
 				if tt.isOk {
 					u := dummyUser
 					t.Logf("token: %s", token)
@@ -92,14 +146,51 @@ func TestAuthorizer(t *testing.T) {
 					if err := dummyUser.ValidateNotEmpty(); err != nil {
 						return nil, err
 					}
-					return u, nil
+					return &entity.UserSession{
+						Id:        1,
+						UserId:    u.Id,
+						Token:     u.Token,
+						IssuedAt:  c.Now().Add(-time.Hour),
+						ExpiresAt: tt.expiresAt,
+						RevokedAt: tt.revokedAt,
+					}, nil
 				}
 				return nil, errors.New("error from mock")
 			}
+			moq.GetUserSessionFromTokenFunc = func(
+				_ context.Context,
+				_ service.Queryer,
+				token entity.UserTokenType,
+			) (*entity.UserSession, error) {
+				if tt.legacy {
+					return nil, fmt.Errorf("from mock: %w", sql.ErrNoRows)
+				}
+				return getSession(token)
+			}
+			moq.MigrateLegacyUserTokenFunc = func(
+				_ context.Context,
+				_ service.QueryerAndExecer,
+				token entity.UserTokenType,
+			) (*entity.UserSession, error) {
+				if !tt.legacy {
+					t.Fatal("legacy token migration must not be called")
+				}
+				return getSession(token)
+			}
+			moq.GetActiveUserBanFunc = func(
+				_ context.Context,
+				_ service.Queryer,
+				_ entity.UserId,
+				_ time.Time,
+			) (*entity.UserBan, error) {
+				return tt.ban, nil
+			}
 
+			db, _ := testutil.TxDB(t)
 			sut := &Authorizer{
-				DB:   nil,
-				Repo: moq,
+				DB:      db,
+				Repo:    moq,
+				Clocker: c,
 			}
 			var err error
 			r, err = sut.FillContext(r)
@@ -123,3 +214,52 @@ func TestAuthorizer(t *testing.T) {
 		})
 	}
 }
+
+// DB から読んでいる間にセッションが無効化された場合、古いセッションをキャッシュしない
+func TestAuthorizerInvalidatedDuringRead(t *testing.T) {
+	t.Parallel()
+
+	c := clock.FixedClocker{}
+	token := entity.UserTokenType(uuid.NewString())
+	cache := store.NewSessionCache(c, time.Minute, 10)
+
+	moq := &AuthRepositoryMock{}
+	moq.GetUserSessionFromTokenFunc = func(
+		_ context.Context,
+		_ service.Queryer,
+		token entity.UserTokenType,
+	) (*entity.UserSession, error) {
+		cache.InvalidateUser(1)
+		return &entity.UserSession{
+			Id:        1,
+			UserId:    1,
+			Token:     token,
+			IssuedAt:  c.Now().Add(-time.Hour),
+			ExpiresAt: c.Now().Add(time.Hour),
+		}, nil
+	}
+	moq.GetActiveUserBanFunc = func(
+		_ context.Context,
+		_ service.Queryer,
+		_ entity.UserId,
+		_ time.Time,
+	) (*entity.UserBan, error) {
+		return nil, nil
+	}
+
+	db, _ := testutil.TxDB(t)
+	sut := &Authorizer{
+		DB:      db,
+		Repo:    moq,
+		Clocker: c,
+		Cache:   cache,
+	}
+	r := httptest.NewRequest(http.MethodGet, "/user/me", nil)
+	r.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+	if _, err := sut.FillContext(r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := cache.Get(token); ok {
+		t.Error("session read before invalidation must not be cached")
+	}
+}
@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+var ErrInvalidJWT = errors.New("invalid jwt")
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Sub string `json:"sub"`
+	Iat int64  `json:"iat"`
+	Exp int64  `json:"exp"`
+}
+
+// 署名付きのアクセストークン (JWT) を発行・検証する
+//
+// - 署名には signingKid の鍵を使い、検証は keys に含まれる全ての鍵で行う (kid によるローテーション)
+// - HS256 の場合は鍵をそのまま、EdDSA の場合は鍵を Ed25519 の seed (32 byte) として扱う
+type JWT struct {
+	Algorithm  string
+	SigningKid string
+	Lifetime   time.Duration
+	Clocker    clock.Clocker
+	keys       map[string][]byte
+}
+
+// keys は kid と base64 (StdEncoding) でエンコードした鍵の組
+func NewJWT(
+	algorithm string,
+	keys map[string]string,
+	signingKid string,
+	lifetime time.Duration,
+	clocker clock.Clocker,
+) (*JWT, error) {
+	if algorithm != JWTAlgorithmHS256 && algorithm != JWTAlgorithmEdDSA {
+		return nil, fmt.Errorf("NewJWT: unsupported algorithm: %s", algorithm)
+	}
+	if _, ok := keys[signingKid]; !ok {
+		return nil, fmt.Errorf("NewJWT: signing key is not found: %q", signingKid)
+	}
+
+	decoded := make(map[string][]byte, len(keys))
+	for kid, key := range keys {
+		b, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("NewJWT: decoding key %q: %w", kid, err)
+		}
+		if algorithm == JWTAlgorithmEdDSA && len(b) != ed25519.SeedSize {
+			return nil, fmt.Errorf("NewJWT: ed25519 seed must be %d bytes: %q", ed25519.SeedSize, kid)
+		}
+		decoded[kid] = b
+	}
+
+	return &JWT{
+		Algorithm:  algorithm,
+		SigningKid: signingKid,
+		Lifetime:   lifetime,
+		Clocker:    clocker,
+		keys:       decoded,
+	}, nil
+}
+
+// opaque なトークン (UUID) と区別する
+func looksLikeJWT(token entity.UserTokenType) bool {
+	return strings.Count(string(token), ".") == 2
+}
+
+func (j *JWT) sign(kid string, signingInput []byte) []byte {
+	key := j.keys[kid]
+	switch j.Algorithm {
+	case JWTAlgorithmEdDSA:
+		return ed25519.Sign(ed25519.NewKeyFromSeed(key), signingInput)
+	default:
+		mac := hmac.New(sha256.New, key)
+		mac.Write(signingInput)
+		return mac.Sum(nil)
+	}
+}
+
+func (j *JWT) verifySignature(kid string, signingInput []byte, signature []byte) bool {
+	key := j.keys[kid]
+	switch j.Algorithm {
+	case JWTAlgorithmEdDSA:
+		return ed25519.Verify(ed25519.NewKeyFromSeed(key).Public().(ed25519.PublicKey), signingInput, signature)
+	default:
+		mac := hmac.New(sha256.New, key)
+		mac.Write(signingInput)
+		return hmac.Equal(mac.Sum(nil), signature)
+	}
+}
+
+// userId を sub とするアクセストークンを発行する
+func (j *JWT) IssueAccessToken(userId entity.UserId) (entity.UserTokenType, time.Time, error) {
+	now := j.Clocker.Now()
+	expiresAt := now.Add(j.Lifetime)
+
+	header, err := json.Marshal(jwtHeader{Alg: j.Algorithm, Typ: "JWT", Kid: j.SigningKid})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("IssueAccessToken: %w", err)
+	}
+	claims, err := json.Marshal(jwtClaims{
+		Sub: strconv.FormatInt(int64(userId), 10),
+		Iat: now.Unix(),
+		Exp: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("IssueAccessToken: %w", err)
+	}
+
+	enc := base64.RawURLEncoding
+	signingInput := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	signature := j.sign(j.SigningKid, []byte(signingInput))
+	return entity.UserTokenType(signingInput + "." + enc.EncodeToString(signature)), expiresAt, nil
+}
+
+// 署名と有効期限を検証し、トークンの userId を返す
+//
+// 期限切れの場合は ErrTokenExpired、それ以外の不正なトークンは ErrInvalidJWT を wrap したエラーを返す
+func (j *JWT) Verify(token entity.UserTokenType) (entity.UserId, error) {
+	fail := func(reason string) (entity.UserId, error) {
+		return entity.UserId(0), fmt.Errorf("%w: %s", ErrInvalidJWT, reason)
+	}
+
+	parts := strings.Split(string(token), ".")
+	if len(parts) != 3 {
+		return fail("malformed token")
+	}
+
+	enc := base64.RawURLEncoding
+	var header jwtHeader
+	if b, err := enc.DecodeString(parts[0]); err != nil || json.Unmarshal(b, &header) != nil {
+		return fail("malformed header")
+	}
+	// alg は設定したものだけを受け付ける ("none" などへのすり替えを防ぐ)
+	if header.Alg != j.Algorithm {
+		return fail("unexpected algorithm")
+	}
+	if _, ok := j.keys[header.Kid]; !ok {
+		return fail("unknown kid")
+	}
+
+	signature, err := enc.DecodeString(parts[2])
+	if err != nil {
+		return fail("malformed signature")
+	}
+	if !j.verifySignature(header.Kid, []byte(parts[0]+"."+parts[1]), signature) {
+		return fail("signature mismatch")
+	}
+
+	var claims jwtClaims
+	if b, err := enc.DecodeString(parts[1]); err != nil || json.Unmarshal(b, &claims) != nil {
+		return fail("malformed claims")
+	}
+	if j.Clocker.Now().Unix() >= claims.Exp {
+		return entity.UserId(0), ErrTokenExpired
+	}
+	userId, err := strconv.ParseInt(claims.Sub, 10, 64)
+	if err != nil || userId == 0 {
+		return fail("invalid subject")
+	}
+	return entity.UserId(userId), nil
+}
@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// FixedClocker から d だけずらした時刻を返す
+type offsetClocker struct {
+	d time.Duration
+}
+
+func (oc offsetClocker) Now() time.Time {
+	return clock.FixedClocker{}.Now().Add(oc.d)
+}
+
+func TestJWT(t *testing.T) {
+	t.Parallel()
+
+	key := func(b byte) string {
+		return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+	}
+	userId := entity.UserId(42)
+
+	tests := map[string]struct {
+		algorithm string
+		// 発行に使う鍵
+		issueKeys map[string]string
+		issueKid  string
+		// 検証に使う鍵
+		verifyKeys map[string]string
+		verifyAt   time.Duration
+		tamper     func(token string) string
+		wantErr    error
+	}{
+		"ok_hs256": {
+			algorithm:  JWTAlgorithmHS256,
+			issueKeys:  map[string]string{"k1": key('a')},
+			issueKid:   "k1",
+			verifyKeys: map[string]string{"k1": key('a')},
+		},
+		"ok_eddsa": {
+			algorithm:  JWTAlgorithmEdDSA,
+			issueKeys:  map[string]string{"k1": key('a')},
+			issueKid:   "k1",
+			verifyKeys: map[string]string{"k1": key('a')},
+		},
+		"ok_rotated": {
+			// 新しい鍵で署名するようになっても古い鍵で署名したトークンを検証できる
+			algorithm:  JWTAlgorithmHS256,
+			issueKeys:  map[string]string{"k1": key('a')},
+			issueKid:   "k1",
+			verifyKeys: map[string]string{"k1": key('a'), "k2": key('b')},
+		},
+		"ng_expired": {
+			algorithm:  JWTAlgorithmHS256,
+			issueKeys:  map[string]string{"k1": key('a')},
+			issueKid:   "k1",
+			verifyKeys: map[string]string{"k1": key('a')},
+			verifyAt:   time.Hour,
+			wantErr:    ErrTokenExpired,
+		},
+		"ng_unknown_kid": {
+			algorithm:  JWTAlgorithmHS256,
+			issueKeys:  map[string]string{"k1": key('a')},
+			issueKid:   "k1",
+			verifyKeys: map[string]string{"k2": key('a')},
+			wantErr:    ErrInvalidJWT,
+		},
+		"ng_wrong_key": {
+			algorithm:  JWTAlgorithmHS256,
+			issueKeys:  map[string]string{"k1": key('a')},
+			issueKid:   "k1",
+			verifyKeys: map[string]string{"k1": key('b')},
+			wantErr:    ErrInvalidJWT,
+		},
+		"ng_tampered_claims": {
+			algorithm:  JWTAlgorithmHS256,
+			issueKeys:  map[string]string{"k1": key('a')},
+			issueKid:   "k1",
+			verifyKeys: map[string]string{"k1": key('a')},
+			tamper: func(token string) string {
+				parts := strings.Split(token, ".")
+				parts[1] = base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"1","iat":0,"exp":9999999999}`))
+				return strings.Join(parts, ".")
+			},
+			wantErr: ErrInvalidJWT,
+		},
+		"ng_alg_none": {
+			algorithm:  JWTAlgorithmHS256,
+			issueKeys:  map[string]string{"k1": key('a')},
+			issueKid:   "k1",
+			verifyKeys: map[string]string{"k1": key('a')},
+			tamper: func(token string) string {
+				parts := strings.Split(token, ".")
+				parts[0] = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT","kid":"k1"}`))
+				parts[2] = ""
+				return strings.Join(parts, ".")
+			},
+			wantErr: ErrInvalidJWT,
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			issuer, err := NewJWT(tt.algorithm, tt.issueKeys, tt.issueKid, 15*time.Minute, clock.FixedClocker{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			verifier, err := NewJWT(tt.algorithm, tt.verifyKeys, firstKid(tt.verifyKeys), 15*time.Minute, offsetClocker{d: tt.verifyAt})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			token, expiresAt, err := issuer.IssueAccessToken(userId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(clock.FixedClocker{}.Now().Add(15*time.Minute), expiresAt); diff != "" {
+				t.Errorf("expiresAt is not match (-want +got)\n%s", diff)
+			}
+			if !looksLikeJWT(token) {
+				t.Errorf("token does not look like jwt: %s", token)
+			}
+			if tt.tamper != nil {
+				token = entity.UserTokenType(tt.tamper(string(token)))
+			}
+
+			got, err := verifier.Verify(token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error is not match: want %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(userId, got); diff != "" {
+				t.Errorf("user id is not match (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func firstKid(keys map[string]string) string {
+	for kid := range keys {
+		return kid
+	}
+	return ""
+}
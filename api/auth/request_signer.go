@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/store"
+)
+
+const (
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+	SignatureNonceHeader     = "X-Signature-Nonce"
+	SignatureHeader          = "X-Signature"
+)
+
+var (
+	ErrSignatureMissing  = errors.New("request signature is missing")
+	ErrSignatureInvalid  = errors.New("request signature is invalid")
+	ErrSignatureExpired  = errors.New("request signature timestamp is out of range")
+	ErrSignatureReplayed = errors.New("request signature nonce is already used")
+)
+
+var validSignatureNonce = regexp.MustCompile(`^[0-9A-Za-z_-]{16,64}$`)
+
+// 認証トークン毎の署名用の鍵 (署名用シークレット) でリクエストの HMAC 署名を検証する
+//
+// 署名用シークレットはサーバーの鍵と認証トークンから導出するため DB には保存しない
+// トークンを発行した時にのみクライアントに渡し、リクエストには含めない
+//
+// 署名は署名用シークレットを鍵とした以下の文字列の HMAC-SHA256 (hex)
+//
+//	<method>\n<path>\n<timestamp (unix 秒)>\n<nonce>\n<body の SHA-256 (hex)>
+type RequestSigner struct {
+	Clocker clock.Clocker
+	// 現在時刻との差が MaxSkew を超えるタイムスタンプは受け付けない
+	MaxSkew time.Duration
+	// 同じ nonce の署名を受け付けない (MaxSkew の 2 倍の間記録する)
+	Nonces *store.NonceStore
+	key    []byte
+}
+
+func NewRequestSigner(key string, maxSkew time.Duration, clocker clock.Clocker) *RequestSigner {
+	return &RequestSigner{
+		Clocker: clocker,
+		MaxSkew: maxSkew,
+		Nonces:  store.NewNonceStore(clocker, 2*maxSkew),
+		key:     []byte(key),
+	}
+}
+
+// token (user_token またはアクセストークン) の署名用シークレットを返す
+func (s *RequestSigner) SigningSecret(token entity.UserTokenType) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte("request-signing:"))
+	mac.Write([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// 署名を検証する (body は r.Body を読み出したもの)
+//
+// ヘッダーが無い場合は ErrSignatureMissing、タイムスタンプが範囲外の場合は ErrSignatureExpired、
+// nonce が使用済みの場合は ErrSignatureReplayed、それ以外は ErrSignatureInvalid を wrap したエラーを返す
+func (s *RequestSigner) Verify(r *http.Request, body []byte) error {
+	timestamp := r.Header.Get(SignatureTimestampHeader)
+	nonce := r.Header.Get(SignatureNonceHeader)
+	signature := r.Header.Get(SignatureHeader)
+	if timestamp == "" || nonce == "" || signature == "" {
+		return ErrSignatureMissing
+	}
+
+	token, err := ExtractBearerToken(r)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSignatureInvalid, err)
+	}
+	if !validSignatureNonce.MatchString(nonce) {
+		return fmt.Errorf("%w: malformed nonce", ErrSignatureInvalid)
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp", ErrSignatureInvalid)
+	}
+	if skew := s.Clocker.Now().Sub(time.Unix(unix, 0)); skew > s.MaxSkew || skew < -s.MaxSkew {
+		return ErrSignatureExpired
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature", ErrSignatureInvalid)
+	}
+
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, []byte(s.SigningSecret(token)))
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%s", r.Method, r.URL.Path, timestamp, nonce, hex.EncodeToString(bodyHash[:]))
+	if !hmac.Equal(mac.Sum(nil), got) {
+		return fmt.Errorf("%w: signature mismatch", ErrSignatureInvalid)
+	}
+
+	// 署名が正しいものだけを記録する (他人の nonce を使用済みにできないように token 毎に分ける)
+	nonceKey := sha256.Sum256([]byte(string(token) + "\n" + nonce))
+	if !s.Nonces.Use(hex.EncodeToString(nonceKey[:])) {
+		return ErrSignatureReplayed
+	}
+	return nil
+}
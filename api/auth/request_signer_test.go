@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// クライアントと同じ手順で署名する
+func signRequest(r *http.Request, secret string, timestamp time.Time, nonce string, body string) {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	bodyHash := sha256.Sum256([]byte(body))
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%s", r.Method, r.URL.Path, ts, nonce, hex.EncodeToString(bodyHash[:]))
+
+	r.Header.Set(SignatureTimestampHeader, ts)
+	r.Header.Set(SignatureNonceHeader, nonce)
+	r.Header.Set(SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func TestRequestSigner(t *testing.T) {
+	t.Parallel()
+
+	const (
+		token = entity.UserTokenType("user-token")
+		nonce = "0123456789abcdef"
+		body  = `{"room_id":1,"score":100}`
+	)
+	now := clock.FixedClocker{}.Now()
+
+	tests := map[string]struct {
+		// 署名に使うシークレットのトークン
+		signToken entity.UserTokenType
+		timestamp time.Time
+		// 署名後に書き換えるボディ (空の場合は書き換えない)
+		sentBody string
+		// 同じリクエストを 2 回送る
+		replay  bool
+		noSign  bool
+		wantErr error
+	}{
+		"ok": {
+			signToken: token,
+			timestamp: now,
+		},
+		"ok_skew": {
+			signToken: token,
+			timestamp: now.Add(-5 * time.Minute),
+		},
+		"ng_missing": {
+			noSign:  true,
+			wantErr: ErrSignatureMissing,
+		},
+		"ng_other_token_secret": {
+			signToken: "other-token",
+			timestamp: now,
+			wantErr:   ErrSignatureInvalid,
+		},
+		"ng_tampered_body": {
+			signToken: token,
+			timestamp: now,
+			sentBody:  `{"room_id":1,"score":999999}`,
+			wantErr:   ErrSignatureInvalid,
+		},
+		"ng_expired": {
+			signToken: token,
+			timestamp: now.Add(-5*time.Minute - time.Second),
+			wantErr:   ErrSignatureExpired,
+		},
+		"ng_replayed": {
+			signToken: token,
+			timestamp: now,
+			replay:    true,
+			wantErr:   ErrSignatureReplayed,
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			s := NewRequestSigner("signing-key", 5*time.Minute, clock.FixedClocker{})
+			sentBody := body
+			if tt.sentBody != "" {
+				sentBody = tt.sentBody
+			}
+			r := httptest.NewRequest(http.MethodPost, "/room/end", strings.NewReader(sentBody))
+			r.Header.Set("Authorization", "Bearer "+string(token))
+			if !tt.noSign {
+				signRequest(r, s.SigningSecret(tt.signToken), tt.timestamp, nonce, body)
+			}
+
+			err := s.Verify(r, []byte(sentBody))
+			if tt.replay {
+				if err != nil {
+					t.Fatalf("unexpected error on first request: %v", err)
+				}
+				err = s.Verify(r, []byte(sentBody))
+			}
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("want error %v, but got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
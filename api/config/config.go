@@ -1,6 +1,9 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/caarlos0/env/v9"
 )
 
@@ -11,6 +14,53 @@ type Config struct {
 	DBUser     string `env:"DB_USER" envDefault:"webapp"`
 	DBPassword string `env:"DB_PASSWORD" envDefault:"webapp_no_password"`
 	DBName     string `env:"DB_NAME" envDefault:"webapp"`
+	// 認証トークンを DB に保存する際の HMAC の鍵 (必須、公開されている値を使わないようにデフォルト値は無い)
+	// 開発環境では Makefile・docker-compose.yml で設定する
+	TokenPepper string `env:"TOKEN_PEPPER,required,notEmpty"`
+	// JWT のアクセストークンを有効にする場合に設定する ("kid:base64key,..." 形式)
+	// 空の場合は opaque なトークンのみを使う
+	JWTKeys       map[string]string `env:"JWT_KEYS"`
+	JWTSigningKid string            `env:"JWT_SIGNING_KID"`
+	// HS256 or EdDSA
+	JWTAlgorithm string `env:"JWT_ALGORITHM" envDefault:"HS256"`
+	// 認証時のセッションのキャッシュに保持する最大数 (0 の場合はキャッシュしない)
+	AuthCacheSize int `env:"AUTH_CACHE_SIZE" envDefault:"10000"`
+	// 管理 API の資格情報 ("name:token,..." 形式、空の場合は管理 API を無効にする)
+	AdminCredentials map[string]string `env:"ADMIN_CREDENTIALS"`
+	// 管理 API は外部に公開しない別のポートで提供する
+	AdminPort int `env:"ADMIN_PORT" envDefault:"8081"`
+	// true の場合は ADMIN_PORT を使わずに PORT の `/admin` で提供する (外部に公開されるため明示的に設定する)
+	AdminOnPublicPort bool `env:"ADMIN_ON_PUBLIC_PORT" envDefault:"false"`
+	// リクエストの署名を有効にする場合に設定する (空の場合は署名用シークレットを発行しない)
+	RequestSigningKey string `env:"REQUEST_SIGNING_KEY"`
+	// 署名を必須にするパス ("/room/end,..." 形式)
+	RequestSigningPaths []string `env:"REQUEST_SIGNING_PATHS" envDefault:"/room/end"`
+	// ユーザー名・チャットで禁止する単語 ("word,..." 形式、NG_WORDS_FILE と併用できる)
+	NGWords []string `env:"NG_WORDS"`
+	// 1 行に 1 単語の NG ワードの辞書のパス
+	NGWordsFile string `env:"NG_WORDS_FILE"`
+	// 空の場合はクライアントのバージョンを確認しない ("1.2.3" 形式)
+	MinClientVersion string `env:"MIN_CLIENT_VERSION"`
+	// バージョンが古い場合にクライアントに返すストアの URL
+	ClientStoreURL string `env:"CLIENT_STORE_URL"`
+	// X-Client-Version ヘッダーが無いリクエストも古いバージョンとして拒否する
+	ClientVersionRequired bool `env:"CLIENT_VERSION_REQUIRED" envDefault:"false"`
+	// ルートグループ毎のレートリミット (トークンバケット)
+	// *_BURST 回まで連続で呼べ、*_INTERVAL 毎に 1 回分回復する
+	UserRateLimitBurst      int           `env:"USER_RATE_LIMIT_BURST" envDefault:"30"`
+	UserRateLimitInterval   time.Duration `env:"USER_RATE_LIMIT_INTERVAL" envDefault:"100ms"`
+	FriendRateLimitBurst    int           `env:"FRIEND_RATE_LIMIT_BURST" envDefault:"30"`
+	FriendRateLimitInterval time.Duration `env:"FRIEND_RATE_LIMIT_INTERVAL" envDefault:"100ms"`
+	RoomRateLimitBurst      int           `env:"ROOM_RATE_LIMIT_BURST" envDefault:"30"`
+	RoomRateLimitInterval   time.Duration `env:"ROOM_RATE_LIMIT_INTERVAL" envDefault:"100ms"`
+	// `/user/create` (IP 毎) と `/room/create` (ユーザー毎) はより厳しく制限する
+	CreateRateLimitBurst    int           `env:"CREATE_RATE_LIMIT_BURST" envDefault:"5"`
+	CreateRateLimitInterval time.Duration `env:"CREATE_RATE_LIMIT_INTERVAL" envDefault:"10s"`
+	// リバースプロキシの背後で動かす場合に未認証のリクエストのクライアントの IP を取得するヘッダー ("X-Forwarded-For" など)
+	// 空の場合は接続元の IP を使う (クライアントが偽装できるため、ヘッダーを上書きするプロキシを経由しない場合は設定しない)
+	TrustedProxyHeader string `env:"TRUSTED_PROXY_HEADER"`
+	// 別のルームに参加中のユーザーが作成・参加した場合に元のルームから自動で退出させる
+	AutoLeaveActiveRoom bool `env:"AUTO_LEAVE_ACTIVE_ROOM" envDefault:"false"`
 }
 
 func New() (*Config, error) {
@@ -18,5 +68,23 @@ func New() (*Config, error) {
 	if err := env.Parse(cfg); err != nil {
 		return nil, err
 	}
+	// 0 以下の場合はトークンが回復しない・制限されなくなるため起動時に拒否する
+	for _, rl := range []struct {
+		name     string
+		burst    int
+		interval time.Duration
+	}{
+		{"USER", cfg.UserRateLimitBurst, cfg.UserRateLimitInterval},
+		{"FRIEND", cfg.FriendRateLimitBurst, cfg.FriendRateLimitInterval},
+		{"ROOM", cfg.RoomRateLimitBurst, cfg.RoomRateLimitInterval},
+		{"CREATE", cfg.CreateRateLimitBurst, cfg.CreateRateLimitInterval},
+	} {
+		if rl.burst <= 0 {
+			return nil, fmt.Errorf("%s_RATE_LIMIT_BURST must be positive: %d", rl.name, rl.burst)
+		}
+		if rl.interval <= 0 {
+			return nil, fmt.Errorf("%s_RATE_LIMIT_INTERVAL must be positive: %v", rl.name, rl.interval)
+		}
+	}
 	return cfg, nil
 }
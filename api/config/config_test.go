@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestNewRateLimit(t *testing.T) {
+	tests := map[string]struct {
+		env     map[string]string
+		wantErr bool
+	}{
+		"ok_default": {
+			env: map[string]string{},
+		},
+		"ng_zero_interval": {
+			// 0 の場合はトークンが回復せず、すべてのリクエストを許可してしまう
+			env:     map[string]string{"ROOM_RATE_LIMIT_INTERVAL": "0s"},
+			wantErr: true,
+		},
+		"ng_negative_interval": {
+			env:     map[string]string{"USER_RATE_LIMIT_INTERVAL": "-1s"},
+			wantErr: true,
+		},
+		"ng_zero_burst": {
+			env:     map[string]string{"CREATE_RATE_LIMIT_BURST": "0"},
+			wantErr: true,
+		},
+	}
+
+	// t.Setenv を使うため並列に実行しない
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Setenv("TOKEN_PEPPER", "test")
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			_, err := New()
+			if tt.wantErr && err == nil {
+				t.Fatal("want error, but got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -1,5 +1,71 @@
 package config
 
+import "time"
+
 const (
+	// 認証トークンの有効期間 (`/user/token/refresh` で更新する)
+	SessionLifetime = 30 * 24 * time.Hour
+	// JWT のアクセストークンの有効期間 (失効できないため短くする)
+	AccessTokenLifetime = 15 * time.Minute
+	// 認証時のセッションをキャッシュする期間
+	// 他のサーバーで無効化されたトークンもこの期間が過ぎれば使えなくなる
+	AuthCacheTTL = 30 * time.Second
+
+	// メンテナンスモードをキャッシュする期間
+	// 管理 API で変更した場合、他のサーバーにはこの期間が過ぎてから反映される
+	MaintenanceCacheTTL = 5 * time.Second
+
+	// 引き継ぎコードの有効期間
+	TransferLifetime = 24 * time.Hour
+	// パスワードを TransferMaxFailedAttempts 回連続で間違えると TransferLockoutDuration の間ロックする
+	TransferMaxFailedAttempts = 5
+	TransferLockoutDuration   = 30 * time.Minute
+
+	// ユーザー名の最大文字数 (書記素クラスタで数える)
+	UserNameMaxLength = 16
+	// ユーザー名の正規化後の最大文字 (rune) 数 (`user`.`name` のカラムの長さ)
+	UserNameMaxRunes = 255
+
 	MaxUserCount = 4
+	// 観戦者は MaxUserCount に含めない
+	MaxSpectatorCount = 16
+
+	// `/room/start` から実際にライブを開始するまでの猶予
+	// 各クライアントが `/room/wait` で開始時刻を受け取れるように数秒先に設定する
+	LiveStartDelay = 3 * time.Second
+	// ライブ開始時刻から `/room/end` を受け付ける期限
+	// 再接続したクライアントもこの期限内であれば結果を送信できる
+	// 期限を過ぎると未送信のメンバーを待たずに `/room/result` を返す
+	ResultDeadline = 5 * time.Minute
+
+	// ChatRateLimitWindow の間に 1 ユーザーが送信できるチャットの数
+	ChatRateLimitCount  = 5
+	ChatRateLimitWindow = 10 * time.Second
+	// チャットの最大文字数 (書記素クラスタで数える)
+	ChatMessageMaxLength = 140
+	// チャットの正規化後の最大文字 (rune) 数 (`room_chat`.`message` のカラムの長さ)
+	ChatMessageMaxRunes = 255
+	// 1 回の `/room/chat` で返すチャットの最大数
+	ChatFetchLimit = 100
+
+	// 招待の有効期間
+	RoomInvitationLifetime = 5 * time.Minute
+
+	// リクエストの署名のタイムスタンプと現在時刻の差の許容範囲
+	RequestSignatureMaxSkew = 5 * time.Minute
+
+	// 監査ログの詳細の最大文字数 (`audit_log`.`detail` のカラムの長さ、超えた分は切り捨てる)
+	AuditLogDetailMaxLength = 1024
+
+	// Idempotency-Key で最初のレスポンスを保存する期間
+	IdempotencyKeyLifetime = 24 * time.Hour
+	// 処理中のキーは IdempotencyKeyLockRenewInterval 毎に IdempotencyKeyLockTimeout 先まで延長する
+	// 延長されなくなった (サーバーの停止などで残った) キーはロックが切れた後に再利用できる
+	IdempotencyKeyLockTimeout       = time.Minute
+	IdempotencyKeyLockRenewInterval = 20 * time.Second
+	// これより大きいレスポンスは保存しない (再送時は再度処理する)
+	IdempotencyMaxResponseSize = 1 << 20
+	// 期限切れのキーは新しいキーを作成した時に IdempotencyKeyPurgeInterval 毎に最大 IdempotencyKeyPurgeLimit 件削除する
+	IdempotencyKeyPurgeInterval = time.Minute
+	IdempotencyKeyPurgeLimit    = 1000
 )
@@ -0,0 +1,59 @@
+package entity
+
+import "time"
+
+type AuditLogId int64
+
+type AuditAction string
+
+const (
+	AuditActionUserCreate AuditAction = "user.create"
+	AuditActionUserUpdate AuditAction = "user.update"
+
+	AuditActionRoomCreate AuditAction = "room.create"
+	AuditActionRoomJoin   AuditAction = "room.join"
+	AuditActionRoomLeave  AuditAction = "room.leave"
+	AuditActionRoomStart  AuditAction = "room.start"
+	AuditActionRoomEnd    AuditAction = "room.end"
+
+	// 管理 API (参照のみの操作も記録する)
+	AuditActionAdminGetUser         AuditAction = "admin.get_user"
+	AuditActionAdminSearchUsers     AuditAction = "admin.search_users"
+	AuditActionAdminResetUserToken  AuditAction = "admin.reset_user_token"
+	AuditActionAdminBanUser         AuditAction = "admin.ban_user"
+	AuditActionAdminUnbanUser       AuditAction = "admin.unban_user"
+	AuditActionAdminGetRoomMembers  AuditAction = "admin.get_room_members"
+	AuditActionAdminDissolveRoom    AuditAction = "admin.dissolve_room"
+	AuditActionAdminDeleteScore     AuditAction = "admin.delete_score"
+	AuditActionAdminGetAuditLogs    AuditAction = "admin.get_audit_logs"
+	AuditActionAdminExportAuditLogs AuditAction = "admin.export_audit_logs"
+	AuditActionAdminGetMaintenance  AuditAction = "admin.get_maintenance"
+	AuditActionAdminSetMaintenance  AuditAction = "admin.set_maintenance"
+)
+
+// 監査ログ (追記のみ)
+type AuditLog struct {
+	Id AuditLogId `db:"id"`
+	// 操作したユーザー (管理者の操作の場合は 0)
+	ActorUserId UserId `db:"actor_user_id"`
+	// 操作した管理者 (ユーザーの操作の場合は空文字列)
+	ActorAdminName string      `db:"actor_admin_name"`
+	Action         AuditAction `db:"action"`
+	// 対象が無い場合は 0
+	TargetUserId UserId `db:"target_user_id"`
+	TargetRoomId RoomId `db:"target_room_id"`
+	RequestId    string `db:"request_id"`
+	// 操作の引数など
+	Detail    string    `db:"detail"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// 0 / 空文字列の条件は指定しないものとして扱う
+type AuditLogFilter struct {
+	// 操作したユーザーまたは対象のユーザー
+	UserId UserId
+	RoomId RoomId
+	Action AuditAction
+	// この id より後のログを返す
+	SinceId AuditLogId
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// "1.2.3" 形式のクライアントのバージョン
+type ClientVersion []int
+
+// 数字を "." で区切った形式 (最大 4 つ) のみを受け付ける
+func ParseClientVersion(s string) (ClientVersion, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) > 4 {
+		return nil, fmt.Errorf("ParseClientVersion: too many components: %q", s)
+	}
+	v := make(ClientVersion, 0, len(parts))
+	for _, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || strings.HasPrefix(p, "+") {
+			return nil, fmt.Errorf("ParseClientVersion: invalid version: %q", s)
+		}
+		v = append(v, n)
+	}
+	return v, nil
+}
+
+// 省略された要素は 0 として比較する ("1.2" と "1.2.0" は等しい)
+func (v ClientVersion) Less(other ClientVersion) bool {
+	for i := 0; i < len(v) || i < len(other); i++ {
+		var a, b int
+		if i < len(v) {
+			a = v[i]
+		}
+		if i < len(other) {
+			b = other[i]
+		}
+		if a != b {
+			return a < b
+		}
+	}
+	return false
+}
+
+func (v ClientVersion) String() string {
+	parts := make([]string, len(v))
+	for i, n := range v {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"testing"
+)
+
+func TestClientVersionLess(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		v     string
+		other string
+		want  bool
+	}{
+		"less_patch":         {v: "1.2.3", other: "1.2.4", want: true},
+		"less_numeric":       {v: "1.9.0", other: "1.10.0", want: true},
+		"equal":              {v: "1.2.3", other: "1.2.3", want: false},
+		"equal_omitted_zero": {v: "1.2", other: "1.2.0", want: false},
+		"less_omitted":       {v: "1.2", other: "1.2.1", want: true},
+		"greater":            {v: "2.0", other: "1.99.99", want: false},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := ParseClientVersion(tt.v)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			other, err := ParseClientVersion(tt.other)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := v.Less(other); got != tt.want {
+				t.Errorf("want %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseClientVersion(t *testing.T) {
+	t.Parallel()
+
+	for _, s := range []string{"", "1.", "v1.2", "1.-2", "1.+2", "1.2.3.4.5", "1.2-beta"} {
+		s := s
+		t.Run(s, func(t *testing.T) {
+			t.Parallel()
+			if _, err := ParseClientVersion(s); err == nil {
+				t.Errorf("want error for %q", s)
+			}
+		})
+	}
+}
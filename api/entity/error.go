@@ -1,13 +1,65 @@
 package entity
 
+import "fmt"
+
 type ErrUnauthorized struct{}
 
 func (e *ErrUnauthorized) Error() string {
 	return "unauthorized"
 }
 
+type ErrTooManyRequests struct{}
+
+func (e *ErrTooManyRequests) Error() string {
+	return "too many requests"
+}
+
 type ErrPermissionDenied struct{}
 
 func (e *ErrPermissionDenied) Error() string {
 	return "permission denied"
 }
+
+// 既に別のルーム (Waiting / LiveStart) に参加している
+type ErrAlreadyInRoom struct {
+	RoomId RoomId
+}
+
+func (e *ErrAlreadyInRoom) Error() string {
+	return fmt.Sprintf("already in room: %d", e.RoomId)
+}
+
+// 対象のユーザー・ルームなどが存在しない
+type ErrNotFound struct{}
+
+func (e *ErrNotFound) Error() string {
+	return "not found"
+}
+
+// 同じ Idempotency-Key で異なるリクエストが送られた
+type ErrIdempotencyKeyMismatch struct{}
+
+func (e *ErrIdempotencyKeyMismatch) Error() string {
+	return "idempotency key reused with different request"
+}
+
+// 同じ Idempotency-Key のリクエストを処理中
+type ErrIdempotencyKeyInProgress struct{}
+
+func (e *ErrIdempotencyKeyInProgress) Error() string {
+	return "request with same idempotency key is in progress"
+}
+
+// 招待の期限が切れている
+type ErrInvitationExpired struct{}
+
+func (e *ErrInvitationExpired) Error() string {
+	return "invitation is expired"
+}
+
+// 招待に既に回答している (同時に承諾・拒否した場合も含む)
+type ErrInvitationAnswered struct{}
+
+func (e *ErrInvitationAnswered) Error() string {
+	return "invitation is already answered"
+}
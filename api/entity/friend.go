@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+type FriendStatus int
+
+const (
+	// Requested	UserId が FriendUserId にフレンド申請している
+	// Accepted	フレンド
+	FriendStatusRequested FriendStatus = 1
+	FriendStatusAccepted  FriendStatus = 2
+)
+
+type Friend struct {
+	UserId       UserId       `db:"user_id"`
+	FriendUserId UserId       `db:"friend_user_id"`
+	Status       FriendStatus `db:"status"`
+	CreatedAt    time.Time    `db:"created_at"`
+	UpdatedAt    time.Time    `db:"updated_at"`
+}
+
+// ユーザーから見た相手との関係
+type FriendRelation int
+
+const (
+	FriendRelationFriend          FriendRelation = 1
+	FriendRelationOutgoingRequest FriendRelation = 2
+	FriendRelationIncomingRequest FriendRelation = 3
+)
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+type IdempotencyKeyType string
+
+// Idempotency-Key ヘッダー付きのリクエストの最初のレスポンス
+type IdempotencyRecord struct {
+	// 認証前のリクエストは 0
+	UserId UserId `db:"user_id"`
+	// 平文のキー (DB には HMAC のみ保存する)
+	Key         IdempotencyKeyType `db:"-"`
+	RequestHash string             `db:"request_hash"`
+	// 処理中の場合は 0
+	StatusCode int `db:"status_code"`
+	// 平文のレスポンス (DB には Key から導出した鍵で暗号化して保存する)
+	ResponseBody []byte `db:"response_body"`
+	// 処理中のリクエストが定期的に延長する
+	LockedUntil time.Time `db:"locked_until"`
+	ExpiresAt   time.Time `db:"expires_at"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.StatusCode != 0
+}
+
+// 処理中のまま延長されなくなった (サーバーの停止などで残った)
+func (r *IdempotencyRecord) IsLockExpired(now time.Time) bool {
+	return !r.IsCompleted() && !now.Before(r.LockedUntil)
+}
+
+func (r *IdempotencyRecord) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
@@ -9,8 +9,10 @@ const (
 	// RoomFull	2	満員
 	// Disbanded	3	解散済み
 	// OtherError	4	その他エラー
+	// Blocked	5	ルームのメンバーにブロックされている
 	JoinRoomResultOk        JoinRoomResult = 1
 	JoinRoomResultRoomFull  JoinRoomResult = 2
 	JoinRoomResultDisbanded JoinRoomResult = 3
 	JoinRoomResultOtherErr  JoinRoomResult = 4
+	JoinRoomResultBlocked   JoinRoomResult = 5
 )
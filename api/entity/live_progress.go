@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// ライブ中に各ユーザーから定期的に報告される途中経過
+type LiveProgress struct {
+	UserId     UserId    `json:"user_id"`
+	Score      int       `json:"score"`
+	Combo      int       `json:"combo"`
+	ReportedAt time.Time `json:"reported_at"`
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// メンテナンスモード
+type Maintenance struct {
+	Enabled bool `db:"enabled"`
+	// ユーザーに表示するメッセージ
+	Message string `db:"message"`
+	// 終了予定時刻 (表示のみで、過ぎても自動では終了しない。未定の場合は nil)
+	EndsAt *time.Time `db:"ends_at"`
+	// 最後に変更した管理者
+	AdminName string    `db:"admin_name"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
@@ -10,8 +10,13 @@ type Room struct {
 	LiveId     LiveId     `db:"live_id"`
 	HostUserId UserId     `db:"host_user_id"`
 	Status     RoomStatus `db:"status"`
-	CreatedAt  time.Time  `db:"created_at"`
-	UpdatedAt  time.Time  `db:"updated_at"`
+	Round      int        `db:"round"`
+	// `/room/rematch` で作成されたルーム (0 は未作成)
+	RematchRoomId RoomId `db:"rematch_room_id"`
+	// 全員が同時にライブを開始する時刻 (Waiting 中は nil)
+	StartAt   *time.Time `db:"start_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
 }
 
 func NewRoom(
@@ -25,6 +30,7 @@ func NewRoom(
 		LiveId:     liveId,
 		HostUserId: hostUseId,
 		Status:     status,
+		Round:      1,
 		CreatedAt:  createdAt,
 		UpdatedAt:  updatedAt,
 	}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+type RoomChatId int64
+type StampId int
+
+// ルーム内チャット
+//
+// Message と StampId のどちらか一方のみを持つ (StampId が 0 の場合はテキストメッセージ)
+type RoomChat struct {
+	Id      RoomChatId `db:"id"`
+	RoomId  RoomId     `db:"room_id"`
+	UserId  UserId     `db:"user_id"`
+	Message string     `db:"message"`
+	StampId StampId    `db:"stamp_id"`
+	// 退出したユーザーのチャットも表示できるように取得時に user table から設定する
+	UserName  string    `db:"user_name"`
+	CreatedAt time.Time `db:"created_at"`
+}
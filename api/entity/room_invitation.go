@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+type RoomInvitationId int64
+
+type RoomInvitationStatus int
+
+const (
+	// Pending	招待中 (ExpiresAt を過ぎたものは無効)
+	// Accepted	招待を受けてルームに参加した
+	// Declined	招待を断った
+	RoomInvitationStatusPending  RoomInvitationStatus = 1
+	RoomInvitationStatusAccepted RoomInvitationStatus = 2
+	RoomInvitationStatusDeclined RoomInvitationStatus = 3
+)
+
+type RoomInvitation struct {
+	Id            RoomInvitationId     `db:"id"`
+	RoomId        RoomId               `db:"room_id"`
+	InviterUserId UserId               `db:"inviter_user_id"`
+	InviteeUserId UserId               `db:"invitee_user_id"`
+	Status        RoomInvitationStatus `db:"status"`
+	ExpiresAt     time.Time            `db:"expires_at"`
+	CreatedAt     time.Time            `db:"created_at"`
+	UpdatedAt     time.Time            `db:"updated_at"`
+}
+
+func (ri *RoomInvitation) IsExpired(now time.Time) bool {
+	return !now.Before(ri.ExpiresAt)
+}
@@ -0,0 +1,10 @@
+package entity
+
+// セットリストの 1 曲分
+//
+// Round 番目のラウンドで LiveId を演奏する
+type RoomSetlistItem struct {
+	RoomId RoomId `db:"room_id"`
+	Round  int    `db:"round"`
+	LiveId LiveId `db:"live_id"`
+}
@@ -22,11 +22,27 @@ func HasScore(roomUserStatus RoomUserStatus) bool {
 	}
 }
 
+type RoomUserRole int
+
+const (
+	// Player	ライブに参加する
+	// Spectator	観戦のみ (定員に含めない・スコアを持たない)
+	RoomUserRolePlayer    RoomUserRole = 1
+	RoomUserRoleSpectator RoomUserRole = 2
+)
+
 type RoomUser struct {
 	RoomId         RoomId         `db:"room_id"`
 	UserId         UserId         `db:"user_id"`
 	LiveDifficulty LiveDifficulty `db:"live_difficulty"`
 	Status         RoomUserStatus `db:"status"`
+	// 再戦を希望しているかどうか
+	Rematch bool         `db:"rematch"`
+	Role    RoomUserRole `db:"role"`
+}
+
+func (ru *RoomUser) IsSpectator() bool {
+	return ru.Role == RoomUserRoleSpectator
 }
 
 func NewRoomUser(
@@ -39,5 +55,6 @@ func NewRoomUser(
 		UserId:         userId,
 		LiveDifficulty: liveDifficulty,
 		Status:         RoomUserStatusWaiting,
+		Role:           RoomUserRolePlayer,
 	}
 }
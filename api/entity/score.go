@@ -9,6 +9,7 @@ package entity
 // - Miss
 type Score struct {
 	RoomId       RoomId `json:"room_id" db:"room_id"`
+	Round        int    `json:"round" db:"round"`
 	UserId       UserId `json:"user_id" db:"user_id"`
 	Score        int    `json:"score" db:"score"`
 	JudgePerfect int    `json:"judge_perfect" db:"judge_perfect"`
@@ -8,14 +8,18 @@ import (
 type UserId int64
 type LeaderCardIdIDType int64
 type UserTokenType string
+type FriendCodeType string
 
 type User struct {
-	Id           UserId             `db:"id"`
-	Name         string             `db:"name"`
+	Id   UserId `db:"id"`
+	Name string `db:"name"`
+	// DB から取得した場合はハッシュ
 	Token        UserTokenType      `db:"token"`
 	LeaderCardId LeaderCardIdIDType `db:"leader_card_id"`
-	CreatedAt    time.Time          `db:"created_at"`
-	UpdatedAt    time.Time          `db:"updated_at"`
+	// 未発行のユーザーは空文字列
+	FriendCode FriendCodeType `db:"friend_code"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at"`
 }
 
 type UserValidationError struct {
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+type UserBanId int64
+
+// 利用停止 (BAN)
+type UserBan struct {
+	Id       UserBanId `db:"id"`
+	UserId   UserId    `db:"user_id"`
+	Reason   string    `db:"reason"`
+	StartsAt time.Time `db:"starts_at"`
+	// 無期限の場合は nil
+	EndsAt *time.Time `db:"ends_at"`
+	// 解除されていない場合は nil
+	LiftedAt  *time.Time `db:"lifted_at"`
+	AdminName string     `db:"admin_name"`
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+func (b *UserBan) IsActive(now time.Time) bool {
+	if b.LiftedAt != nil || now.Before(b.StartsAt) {
+		return false
+	}
+	return b.EndsAt == nil || now.Before(*b.EndsAt)
+}
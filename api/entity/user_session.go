@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+type UserSessionId int64
+
+type UserSession struct {
+	Id     UserSessionId `db:"id"`
+	UserId UserId        `db:"user_id"`
+	// 平文のトークン (DB にはハッシュのみ保存するため、発行時・認証時のみ設定される)
+	Token     UserTokenType `db:"-"`
+	IssuedAt  time.Time     `db:"issued_at"`
+	ExpiresAt time.Time     `db:"expires_at"`
+	// 無効化されていない場合は nil
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+func (s *UserSession) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+func (s *UserSession) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
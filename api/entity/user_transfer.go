@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+type TransferIdType string
+
+// 引き継ぎ (機種変更) 用のコード
+type UserTransfer struct {
+	UserId       UserId         `db:"user_id"`
+	TransferId   TransferIdType `db:"transfer_id"`
+	PasswordHash string         `db:"password_hash"`
+	ExpiresAt    time.Time      `db:"expires_at"`
+	FailedCount  int            `db:"failed_count"`
+	// ロックされていない場合は nil
+	LockedUntil *time.Time `db:"locked_until"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+func (t *UserTransfer) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+func (t *UserTransfer) IsLocked(now time.Time) bool {
+	return t.LockedUntil != nil && now.Before(*t.LockedUntil)
+}
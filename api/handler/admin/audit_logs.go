@@ -0,0 +1,135 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out audit_logs_moq_test.go . AuditLogsService
+type AuditLogsService interface {
+	GetAuditLogs(
+		ctx context.Context,
+		filter *entity.AuditLogFilter,
+		limit int,
+	) ([]*entity.AuditLog, error)
+}
+
+type AuditLogs struct {
+	Service   AuditLogsService
+	Validator *validator.Validate
+}
+
+type AuditLogJson struct {
+	Id             entity.AuditLogId  `json:"id"`
+	ActorUserId    entity.UserId      `json:"actor_user_id"`
+	ActorAdminName string             `json:"actor_admin_name"`
+	Action         entity.AuditAction `json:"action"`
+	TargetUserId   entity.UserId      `json:"target_user_id"`
+	TargetRoomId   entity.RoomId      `json:"target_room_id"`
+	RequestId      string             `json:"request_id"`
+	Detail         string             `json:"detail"`
+	CreatedAt      time.Time          `json:"created_at"`
+}
+
+func NewAuditLogJson(log *entity.AuditLog) *AuditLogJson {
+	return &AuditLogJson{
+		Id:             log.Id,
+		ActorUserId:    log.ActorUserId,
+		ActorAdminName: log.ActorAdminName,
+		Action:         log.Action,
+		TargetUserId:   log.TargetUserId,
+		TargetRoomId:   log.TargetRoomId,
+		RequestId:      log.RequestId,
+		Detail:         log.Detail,
+		CreatedAt:      log.CreatedAt,
+	}
+}
+
+type auditLogQuery struct {
+	UserId  entity.UserId      `validate:"min=0"`
+	RoomId  entity.RoomId      `validate:"min=0"`
+	Action  entity.AuditAction `validate:"max=64"`
+	SinceId entity.AuditLogId  `validate:"min=0"`
+	Limit   int64              `validate:"min=1,max=1000"`
+}
+
+// 不正なクエリパラメータの場合はレスポンスを書き込んで false を返す
+func parseAuditLogQuery(w http.ResponseWriter, r *http.Request, v *validator.Validate) (*auditLogQuery, bool) {
+	ctx := r.Context()
+	query := &auditLogQuery{
+		Action: entity.AuditAction(r.URL.Query().Get("action")),
+		Limit:  100,
+	}
+	for _, p := range []struct {
+		key string
+		dst *int64
+	}{
+		{key: "user_id", dst: (*int64)(&query.UserId)},
+		{key: "room_id", dst: (*int64)(&query.RoomId)},
+		{key: "since_id", dst: (*int64)(&query.SinceId)},
+		{key: "limit", dst: &query.Limit},
+	} {
+		if err := parseIntQuery(r, p.key, p.dst); err != nil {
+			handler.RespondJson(ctx, w, &handler.ErrResponse{
+				Message: "invalid query parameter: " + p.key,
+				Details: []string{err.Error()},
+			}, http.StatusBadRequest)
+			return nil, false
+		}
+	}
+
+	if err := v.Struct(query); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return nil, false
+	}
+	return query, true
+}
+
+func (q *auditLogQuery) filter() *entity.AuditLogFilter {
+	return &entity.AuditLogFilter{
+		UserId:  q.UserId,
+		RoomId:  q.RoomId,
+		Action:  q.Action,
+		SinceId: q.SinceId,
+	}
+}
+
+// GET /admin/audit_log?user_id=<user_id>&room_id=<room_id>&action=<action>&since_id=<id>&limit=<limit>
+func (al *AuditLogs) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query, ok := parseAuditLogQuery(w, r, al.Validator)
+	if !ok {
+		return
+	}
+
+	logs, err := al.Service.GetAuditLogs(ctx, query.filter(), int(query.Limit))
+	if err != nil {
+		respondServiceError(ctx, w, err)
+		return
+	}
+
+	nextSinceId := query.SinceId
+	logList := make([]*AuditLogJson, len(logs))
+	for i, log := range logs {
+		logList[i] = NewAuditLogJson(log)
+		nextSinceId = log.Id
+	}
+
+	rsp := struct {
+		LogList []*AuditLogJson `json:"log_list"`
+		// 次回の `since_id` に指定する値
+		NextSinceId entity.AuditLogId `json:"next_since_id"`
+	}{
+		LogList:     logList,
+		NextSinceId: nextSinceId,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/store"
+)
+
+type AuthCacheStatsGetter interface {
+	Stats() store.SessionCacheStats
+}
+
+// AUTH_CACHE_SIZE が 0 の場合 (キャッシュしない) は Cache に nil を設定する
+type AuthCacheStats struct {
+	Cache AuthCacheStatsGetter
+}
+
+type AuthCacheStatsJson struct {
+	Enabled bool   `json:"enabled"`
+	Hits    uint64 `json:"hits"`
+	Misses  uint64 `json:"misses"`
+	Size    int    `json:"size"`
+}
+
+// GET /admin/auth_cache
+//
+// 認証時のセッションのキャッシュの統計 (サーバー毎の値で、他のサーバーとは共有しない)
+func (ac *AuthCacheStats) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rsp := AuthCacheStatsJson{}
+	if ac.Cache != nil {
+		stats := ac.Cache.Stats()
+		rsp = AuthCacheStatsJson{
+			Enabled: true,
+			Hits:    stats.Hits,
+			Misses:  stats.Misses,
+			Size:    stats.Size,
+		}
+	}
+	handler.RespondJson(r.Context(), w, rsp, http.StatusOK)
+}
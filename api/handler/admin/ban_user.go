@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out ban_user_moq_test.go . BanUserService
+type BanUserService interface {
+	BanUser(
+		ctx context.Context,
+		userId entity.UserId,
+		reason string,
+		duration time.Duration,
+	) (*entity.UserBan, error)
+}
+
+type BanUser struct {
+	Service   BanUserService
+	Validator *validator.Validate
+}
+
+type BanUserRequestJson struct {
+	UserId entity.UserId `json:"user_id" validate:"required"`
+	// ユーザーにも表示される
+	Reason string `json:"reason" validate:"required,max=255"`
+	// 0 (省略時) は無期限
+	DurationSeconds int64 `json:"duration_seconds" validate:"min=0"`
+}
+
+type UserBanJson struct {
+	Reason   string    `json:"reason"`
+	StartsAt time.Time `json:"starts_at"`
+	// 無期限の場合は null
+	EndsAt *time.Time `json:"ends_at"`
+}
+
+func NewUserBanJson(ban *entity.UserBan) *UserBanJson {
+	if ban == nil {
+		return nil
+	}
+	return &UserBanJson{
+		Reason:   ban.Reason,
+		StartsAt: ban.StartsAt,
+		EndsAt:   ban.EndsAt,
+	}
+}
+
+// POST /admin/user/ban
+func (bu *BanUser) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var body BanUserRequestJson
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := bu.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	ban, err := bu.Service.BanUser(ctx, body.UserId, body.Reason, time.Duration(body.DurationSeconds)*time.Second)
+	if err != nil {
+		respondServiceError(ctx, w, err)
+		return
+	}
+
+	handler.RespondJson(ctx, w, NewUserBanJson(ban), http.StatusOK)
+}
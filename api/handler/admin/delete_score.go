@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out delete_score_moq_test.go . DeleteScoreService
+type DeleteScoreService interface {
+	DeleteScore(
+		ctx context.Context,
+		roomId entity.RoomId,
+		round int,
+		userId entity.UserId,
+	) error
+}
+
+type DeleteScore struct {
+	Service   DeleteScoreService
+	Validator *validator.Validate
+}
+
+type DeleteScoreRequestJson struct {
+	RoomId entity.RoomId `json:"room_id" validate:"required"`
+	Round  int           `json:"round" validate:"required,min=1"`
+	UserId entity.UserId `json:"user_id" validate:"required"`
+}
+
+// POST /admin/score/delete
+func (ds *DeleteScore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var body DeleteScoreRequestJson
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ds.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := ds.Service.DeleteScore(ctx, body.RoomId, body.Round, body.UserId); err != nil {
+		respondServiceError(ctx, w, err)
+		return
+	}
+
+	rsp := struct{}{}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out dissolve_room_moq_test.go . DissolveRoomService
+type DissolveRoomService interface {
+	DissolveRoom(
+		ctx context.Context,
+		roomId entity.RoomId,
+	) error
+}
+
+type DissolveRoom struct {
+	Service   DissolveRoomService
+	Validator *validator.Validate
+}
+
+type DissolveRoomRequestJson struct {
+	RoomId entity.RoomId `json:"room_id" validate:"required"`
+}
+
+// POST /admin/room/dissolve
+func (dr *DissolveRoom) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var body DissolveRoomRequestJson
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := dr.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := dr.Service.DissolveRoom(ctx, body.RoomId); err != nil {
+		respondServiceError(ctx, w, err)
+		return
+	}
+
+	rsp := struct{}{}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
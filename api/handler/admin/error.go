@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+)
+
+func respondServiceError(ctx context.Context, w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if errors.As(err, new(*entity.ErrNotFound)) {
+		status = http.StatusNotFound
+	}
+	handler.RespondJson(ctx, w, &handler.ErrResponse{
+		Message: err.Error(),
+	}, status)
+}
+
+// クエリパラメータを整数として読み込む (省略時は dst を変更しない)
+func parseIntQuery(r *http.Request, key string, dst *int64) error {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return err
+	}
+	*dst = n
+	return nil
+}
@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out export_audit_logs_moq_test.go . ExportAuditLogsService
+type ExportAuditLogsService interface {
+	ExportAuditLogs(
+		ctx context.Context,
+		filter *entity.AuditLogFilter,
+		pageSize int,
+		f func(log *entity.AuditLog) error,
+	) error
+}
+
+type ExportAuditLogs struct {
+	Service   ExportAuditLogsService
+	Validator *validator.Validate
+}
+
+// GET /admin/audit_log/export?user_id=<user_id>&room_id=<room_id>&action=<action>&since_id=<id>
+//
+// 条件に一致する全てのログを 1 行 1 件の JSON (JSONL) で返す (limit は 1 回に DB から取得する件数)
+func (el *ExportAuditLogs) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query, ok := parseAuditLogQuery(w, r, el.Validator)
+	if !ok {
+		return
+	}
+
+	// 最初の 1 件を書き込むまではエラーを JSON で返せる
+	started := false
+	enc := json.NewEncoder(w)
+	err := el.Service.ExportAuditLogs(ctx, query.filter(), int(query.Limit), func(l *entity.AuditLog) error {
+		if !started {
+			w.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			started = true
+		}
+		return enc.Encode(NewAuditLogJson(l))
+	})
+	if err != nil {
+		if !started {
+			respondServiceError(ctx, w, err)
+			return
+		}
+		// ステータスコードは送信済みのため、途中で打ち切る
+		log.Printf("failed to export audit logs: %v", err)
+		return
+	}
+	if !started {
+		w.Header().Set("Content-Type", "application/x-ndjson; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out get_maintenance_moq_test.go . GetMaintenanceService
+type GetMaintenanceService interface {
+	GetMaintenance(ctx context.Context) (*entity.Maintenance, error)
+}
+
+type GetMaintenance struct {
+	Service   GetMaintenanceService
+	Validator *validator.Validate
+}
+
+type MaintenanceJson struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message"`
+	// 未定の場合は null
+	EndsAt    *time.Time `json:"ends_at"`
+	AdminName string     `json:"admin_name"`
+	// 一度も設定されていない場合はゼロ値
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewMaintenanceJson(m *entity.Maintenance) *MaintenanceJson {
+	return &MaintenanceJson{
+		Enabled:   m.Enabled,
+		Message:   m.Message,
+		EndsAt:    m.EndsAt,
+		AdminName: m.AdminName,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// GET /admin/maintenance
+func (gm *GetMaintenance) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	m, err := gm.Service.GetMaintenance(ctx)
+	if err != nil {
+		respondServiceError(ctx, w, err)
+		return
+	}
+
+	handler.RespondJson(ctx, w, NewMaintenanceJson(m), http.StatusOK)
+}
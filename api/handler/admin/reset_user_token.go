@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out reset_user_token_moq_test.go . ResetUserTokenService
+type ResetUserTokenService interface {
+	ResetUserToken(
+		ctx context.Context,
+		userId entity.UserId,
+	) (*entity.UserSession, error)
+}
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out signing_secret_issuer_moq_test.go . SigningSecretIssuer
+type SigningSecretIssuer interface {
+	SigningSecret(token entity.UserTokenType) string
+}
+
+type ResetUserToken struct {
+	Service ResetUserTokenService
+	// nil の場合は署名用シークレットを返さない
+	Signer    SigningSecretIssuer
+	Validator *validator.Validate
+}
+
+type ResetUserTokenRequestJson struct {
+	UserId entity.UserId `json:"user_id" validate:"required"`
+}
+
+// POST /admin/user/reset_token
+func (ru *ResetUserToken) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var body ResetUserTokenRequestJson
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	session, err := ru.Service.ResetUserToken(ctx, body.UserId)
+	if err != nil {
+		respondServiceError(ctx, w, err)
+		return
+	}
+
+	rsp := struct {
+		Token     entity.UserTokenType `json:"user_token"`
+		ExpiresAt time.Time            `json:"expires_at"`
+		// リクエストの署名が有効な場合のみ (ユーザーに渡してリクエストの署名に使う)
+		SigningSecret string `json:"signing_secret,omitempty"`
+	}{
+		Token:     session.Token,
+		ExpiresAt: session.ExpiresAt,
+	}
+	if ru.Signer != nil {
+		rsp.SigningSecret = ru.Signer.SigningSecret(session.Token)
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out room_members_moq_test.go . RoomMembersService
+type RoomMembersService interface {
+	GetRoomMembers(
+		ctx context.Context,
+		roomId entity.RoomId,
+	) (*service.AdminRoomDetail, error)
+}
+
+type RoomMembers struct {
+	Service   RoomMembersService
+	Validator *validator.Validate
+}
+
+type RoomMemberJson struct {
+	UserId         entity.UserId         `json:"user_id"`
+	LiveDifficulty entity.LiveDifficulty `json:"live_difficulty"`
+	Status         entity.RoomUserStatus `json:"status"`
+	Role           entity.RoomUserRole   `json:"role"`
+}
+
+// GET /admin/room/members?room_id=<room_id>
+func (rm *RoomMembers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var query struct {
+		RoomId entity.RoomId `validate:"required"`
+	}
+	if err := parseIntQuery(r, "room_id", (*int64)(&query.RoomId)); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "invalid query parameter: room_id",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := rm.Validator.Struct(query); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	detail, err := rm.Service.GetRoomMembers(ctx, query.RoomId)
+	if err != nil {
+		respondServiceError(ctx, w, err)
+		return
+	}
+
+	memberList := make([]*RoomMemberJson, len(detail.RoomUsers))
+	for i, roomUser := range detail.RoomUsers {
+		memberList[i] = &RoomMemberJson{
+			UserId:         roomUser.UserId,
+			LiveDifficulty: roomUser.LiveDifficulty,
+			Status:         roomUser.Status,
+			Role:           roomUser.Role,
+		}
+	}
+
+	rsp := struct {
+		RoomId     entity.RoomId     `json:"room_id"`
+		LiveId     entity.LiveId     `json:"live_id"`
+		HostUserId entity.UserId     `json:"host_user_id"`
+		Status     entity.RoomStatus `json:"status"`
+		Round      int               `json:"round"`
+		CreatedAt  time.Time         `json:"created_at"`
+		MemberList []*RoomMemberJson `json:"member_list"`
+	}{
+		RoomId:     detail.Room.Id,
+		LiveId:     detail.Room.LiveId,
+		HostUserId: detail.Room.HostUserId,
+		Status:     detail.Room.Status,
+		Round:      detail.Room.Round,
+		CreatedAt:  detail.Room.CreatedAt,
+		MemberList: memberList,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
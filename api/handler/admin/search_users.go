@@ -0,0 +1,69 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out search_users_moq_test.go . SearchUsersService
+type SearchUsersService interface {
+	SearchUsers(
+		ctx context.Context,
+		namePrefix string,
+		limit int,
+	) ([]*entity.User, error)
+}
+
+type SearchUsers struct {
+	Service   SearchUsersService
+	Validator *validator.Validate
+}
+
+// GET /admin/user/search?name=<name_prefix>&limit=<limit>
+func (su *SearchUsers) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := struct {
+		Name  string `validate:"required"`
+		Limit int64  `validate:"min=1,max=100"`
+	}{
+		Name:  r.URL.Query().Get("name"),
+		Limit: 20,
+	}
+	if err := parseIntQuery(r, "limit", &query.Limit); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "invalid query parameter: limit",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := su.Validator.Struct(query); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	users, err := su.Service.SearchUsers(ctx, query.Name, int(query.Limit))
+	if err != nil {
+		respondServiceError(ctx, w, err)
+		return
+	}
+
+	userList := make([]*UserJson, len(users))
+	for i, user := range users {
+		userList[i] = NewUserJson(user)
+	}
+
+	rsp := struct {
+		UserList []*UserJson `json:"user_list"`
+	}{
+		UserList: userList,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
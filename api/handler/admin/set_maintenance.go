@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out set_maintenance_moq_test.go . SetMaintenanceService
+type SetMaintenanceService interface {
+	SetMaintenance(
+		ctx context.Context,
+		enabled bool,
+		message string,
+		endsAt *time.Time,
+	) (*entity.Maintenance, error)
+}
+
+type SetMaintenance struct {
+	Service   SetMaintenanceService
+	Validator *validator.Validate
+}
+
+type SetMaintenanceRequestJson struct {
+	Enabled bool `json:"enabled"`
+	// ユーザーに表示される (開始する場合は必須)
+	Message string `json:"message" validate:"required_if=Enabled true,max=255"`
+	// 終了予定時刻 (RFC 3339、省略時は未定)
+	EndsAt *time.Time `json:"ends_at"`
+}
+
+// POST /admin/maintenance
+//
+// ヘルスチェックと管理 API 以外のリクエストに 503 を返すようになる
+func (sm *SetMaintenance) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var body SetMaintenanceRequestJson
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := sm.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	m, err := sm.Service.SetMaintenance(ctx, body.Enabled, body.Message, body.EndsAt)
+	if err != nil {
+		respondServiceError(ctx, w, err)
+		return
+	}
+
+	handler.RespondJson(ctx, w, NewMaintenanceJson(m), http.StatusOK)
+}
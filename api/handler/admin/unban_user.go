@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out unban_user_moq_test.go . UnbanUserService
+type UnbanUserService interface {
+	UnbanUser(
+		ctx context.Context,
+		userId entity.UserId,
+	) error
+}
+
+type UnbanUser struct {
+	Service   UnbanUserService
+	Validator *validator.Validate
+}
+
+type UnbanUserRequestJson struct {
+	UserId entity.UserId `json:"user_id" validate:"required"`
+}
+
+// POST /admin/user/unban
+func (uu *UnbanUser) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var body UnbanUserRequestJson
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := uu.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := uu.Service.UnbanUser(ctx, body.UserId); err != nil {
+		respondServiceError(ctx, w, err)
+		return
+	}
+
+	rsp := struct{}{}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,88 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out user_moq_test.go . GetUserService
+type GetUserService interface {
+	GetUser(
+		ctx context.Context,
+		userId entity.UserId,
+	) (*service.AdminUserDetail, error)
+}
+
+type GetUser struct {
+	Service   GetUserService
+	Validator *validator.Validate
+}
+
+// トークンは含めない
+type UserJson struct {
+	Id           entity.UserId             `json:"id"`
+	Name         string                    `json:"name"`
+	LeaderCardId entity.LeaderCardIdIDType `json:"leader_card_id"`
+	FriendCode   entity.FriendCodeType     `json:"friend_code"`
+	CreatedAt    time.Time                 `json:"created_at"`
+	UpdatedAt    time.Time                 `json:"updated_at"`
+}
+
+func NewUserJson(user *entity.User) *UserJson {
+	return &UserJson{
+		Id:           user.Id,
+		Name:         user.Name,
+		LeaderCardId: user.LeaderCardId,
+		FriendCode:   user.FriendCode,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+	}
+}
+
+// GET /admin/user?user_id=<user_id>
+func (gu *GetUser) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var query struct {
+		UserId entity.UserId `validate:"required"`
+	}
+	if err := parseIntQuery(r, "user_id", (*int64)(&query.UserId)); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "invalid query parameter: user_id",
+			Details: []string{err.Error()},
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := gu.Validator.Struct(query); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	detail, err := gu.Service.GetUser(ctx, query.UserId)
+	if err != nil {
+		respondServiceError(ctx, w, err)
+		return
+	}
+
+	rsp := struct {
+		User *UserJson `json:"user"`
+		// 参加中のルームが無い場合は 0
+		ActiveRoomId entity.RoomId `json:"active_room_id"`
+		// 有効な BAN が無い場合は null
+		Ban *UserBanJson `json:"ban"`
+	}{
+		User:         NewUserJson(detail.User),
+		ActiveRoomId: detail.ActiveRoomId,
+		Ban:          NewUserBanJson(detail.Ban),
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
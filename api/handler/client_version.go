@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+const (
+	ClientVersionHeader = "X-Client-Version"
+	// クライアントは code で更新が必要かどうかを判断する
+	ClientVersionTooOldCode = "client_version_too_old"
+)
+
+// クライアントの更新が必要な場合のレスポンス
+type UpgradeRequiredResponse struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	MinVersion string `json:"min_version"`
+	StoreUrl   string `json:"store_url"`
+}
+
+// X-Client-Version が minVersion より古いリクエストに 426 を返す
+//
+// required が false の場合、ヘッダーが無いリクエストは通常通り処理する
+func ClientVersionMiddleware(
+	minVersion entity.ClientVersion,
+	storeUrl string,
+	required bool,
+) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isServiceGateExempt(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			header := r.Header.Get(ClientVersionHeader)
+			if header == "" && !required {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// 解釈できないバージョンも古いものとして扱う
+			v, err := entity.ParseClientVersion(header)
+			if err == nil && !v.Less(minVersion) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			RespondJson(r.Context(), w, UpgradeRequiredResponse{
+				Code:       ClientVersionTooOldCode,
+				Message:    fmt.Sprintf("client version %s or later is required", minVersion),
+				MinVersion: minVersion.String(),
+				StoreUrl:   storeUrl,
+			}, http.StatusUpgradeRequired)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestClientVersionMiddleware(t *testing.T) {
+	t.Parallel()
+
+	minVersion, err := entity.ParseClientVersion("1.2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+
+	tests := map[string]struct {
+		path     string
+		header   string
+		required bool
+		want     want
+	}{
+		"ok": {
+			path:   "/user/me",
+			header: "1.2.0",
+			want: want{
+				status: http.StatusOK,
+			},
+		},
+		"ok_newer": {
+			path:   "/user/me",
+			header: "1.10",
+			want: want{
+				status: http.StatusOK,
+			},
+		},
+		"ok_missing_header": {
+			path: "/user/me",
+			want: want{
+				status: http.StatusOK,
+			},
+		},
+		"ok_health": {
+			path:     "/health",
+			header:   "1.0.0",
+			required: true,
+			want: want{
+				status: http.StatusOK,
+			},
+		},
+		"ok_admin": {
+			path:     "/admin/maintenance",
+			header:   "1.0.0",
+			required: true,
+			want: want{
+				status: http.StatusOK,
+			},
+		},
+		"too_old": {
+			path:   "/user/me",
+			header: "1.1.9",
+			want: want{
+				status:  http.StatusUpgradeRequired,
+				rspFile: "testdata/client_version/too_old/res.json.golden",
+			},
+		},
+		"missing_header_required": {
+			path:     "/user/me",
+			required: true,
+			want: want{
+				status:  http.StatusUpgradeRequired,
+				rspFile: "testdata/client_version/missing_header_required/res.json.golden",
+			},
+		},
+		"invalid_header": {
+			// 解釈できないバージョンも古いものとして扱う
+			path:   "/user/me",
+			header: "latest",
+			want: want{
+				status:  http.StatusUpgradeRequired,
+				rspFile: "testdata/client_version/invalid_header/res.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				r.Header.Set(ClientVersionHeader, tt.header)
+			}
+			ClientVersionMiddleware(minVersion, "https://example.com/store", tt.required)(next).ServeHTTP(w, r)
+
+			rsp := w.Result()
+			var body []byte
+			if tt.want.rspFile != "" {
+				body = testutil.LoadFile(t, tt.want.rspFile)
+			}
+			testutil.AssertResponse(t, rsp, tt.want.status, body)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package friend
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out friend_list_moq_test.go . FriendListService
+type FriendListService interface {
+	GetFriendList(
+		ctx context.Context,
+		userId entity.UserId,
+	) ([]*service.FriendListItem, error)
+}
+
+type FriendList struct {
+	Service   FriendListService
+	Validator *validator.Validate
+}
+
+type FriendListResponseJsonItem struct {
+	UserId       entity.UserId             `json:"user_id"`
+	Name         string                    `json:"name"`
+	LeaderCardId entity.LeaderCardIdIDType `json:"leader_card_id"`
+	Relation     entity.FriendRelation     `json:"relation"`
+}
+
+func (ru *FriendList) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	friends, err := ru.Service.GetFriendList(ctx, userId)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	friendList := make([]*FriendListResponseJsonItem, len(friends))
+	for i, f := range friends {
+		friendList[i] = &FriendListResponseJsonItem{
+			UserId:       f.UserId,
+			Name:         f.Name,
+			LeaderCardId: f.LeaderCardId,
+			Relation:     f.Relation,
+		}
+	}
+
+	rsp := struct {
+		FriendList []*FriendListResponseJsonItem `json:"friend_list"`
+	}{
+		FriendList: friendList,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
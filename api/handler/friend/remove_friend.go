@@ -0,0 +1,71 @@
+package friend
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out remove_friend_moq_test.go . RemoveFriendService
+type RemoveFriendService interface {
+	RemoveFriend(
+		ctx context.Context,
+		userId entity.UserId,
+		friendUserId entity.UserId,
+	) error
+}
+
+type RemoveFriend struct {
+	Service   RemoveFriendService
+	Validator *validator.Validate
+}
+
+func (ru *RemoveFriend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		UserId entity.UserId `json:"user_id" validate:"required"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Service.RemoveFriend(
+		ctx,
+		userId,
+		body.UserId,
+	); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	rsp := struct{}{}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
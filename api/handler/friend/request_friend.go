@@ -0,0 +1,77 @@
+package friend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out request_friend_moq_test.go . RequestFriendService
+type RequestFriendService interface {
+	RequestFriend(
+		ctx context.Context,
+		userId entity.UserId,
+		friendCode entity.FriendCodeType,
+	) (entity.FriendRelation, error)
+}
+
+type RequestFriend struct {
+	Service   RequestFriendService
+	Validator *validator.Validate
+}
+
+func (ru *RequestFriend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		FriendCode entity.FriendCodeType `json:"friend_code" validate:"required"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	relation, err := ru.Service.RequestFriend(ctx, userId, body.FriendCode)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.As(err, new(*entity.ErrPermissionDenied)) {
+			status = http.StatusForbidden
+		}
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, status)
+		return
+	}
+
+	rsp := struct {
+		Relation entity.FriendRelation `json:"relation"`
+	}{
+		Relation: relation,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
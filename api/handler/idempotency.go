@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// 保存したレスポンスを返した場合に付与する
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// 推測できない値 (UUID など) に限る
+var validIdempotencyKey = regexp.MustCompile(`^[0-9A-Za-z_.:-]{16,255}$`)
+
+// Idempotency-Key ヘッダーがある場合、(ユーザー, キー) 毎に最初のレスポンスを保存し、再送には同じレスポンスを返す
+//
+// 5xx のレスポンスは保存せず、再送時に再度処理する
+// 認証前のリクエストは同じキーでもボディが異なれば別のリクエストとして扱う
+// ユーザー毎にキーを分けるには AuthMiddleware の内側で使う
+func IdempotencyMiddleware(s *service.Idempotency) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx := r.Context()
+			if !validIdempotencyKey.MatchString(key) {
+				RespondJson(ctx, w, ErrResponse{
+					Message: "invalid idempotency key",
+					Details: []string{"must be 16-255 characters of [0-9A-Za-z_.:-]"},
+				}, http.StatusBadRequest)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				RespondJson(ctx, w, ErrResponse{
+					Message: err.Error(),
+				}, http.StatusInternalServerError)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			// 認証前は 0
+			userId, _ := service.GetUserId(ctx)
+			idemKey := entity.IdempotencyKeyType(key)
+			hash := requestHash(r, body)
+			record, err := s.Begin(ctx, userId, idemKey, hash)
+			if err != nil {
+				var mismatch *entity.ErrIdempotencyKeyMismatch
+				var inProgress *entity.ErrIdempotencyKeyInProgress
+				switch {
+				case errors.As(err, &mismatch):
+					RespondJson(ctx, w, ErrResponse{
+						Message: "idempotency key reused",
+						Details: []string{err.Error()},
+					}, http.StatusUnprocessableEntity)
+				case errors.As(err, &inProgress):
+					RespondJson(ctx, w, ErrResponse{
+						Message: "idempotency key in progress",
+						Details: []string{err.Error()},
+					}, http.StatusConflict)
+				default:
+					RespondJson(ctx, w, ErrResponse{
+						Message: err.Error(),
+					}, http.StatusInternalServerError)
+				}
+				return
+			}
+			if record != nil {
+				w.Header().Set("Content-Type", "application/json; charset=utf-8")
+				w.Header().Set(IdempotentReplayedHeader, "true")
+				w.WriteHeader(record.StatusCode)
+				if _, err := w.Write(record.ResponseBody); err != nil {
+					log.Printf("failed to write response: %v", err)
+				}
+				return
+			}
+
+			// 処理が終わるまでロックを延長し、処理中の再送を 409 にする
+			done := make(chan struct{})
+			go func() {
+				ticker := time.NewTicker(config.IdempotencyKeyLockRenewInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-done:
+						return
+					case <-ticker.C:
+						if err := s.Renew(ctx, userId, idemKey, hash); err != nil {
+							log.Printf("failed to renew idempotency key: %v", err)
+						}
+					}
+				}
+			}()
+
+			rec := &responseRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			close(done)
+
+			if rec.statusCode >= http.StatusInternalServerError || rec.overflow {
+				if err := s.Abort(ctx, userId, idemKey, hash); err != nil {
+					log.Printf("failed to abort idempotency key: %v", err)
+				}
+				return
+			}
+			if err := s.Complete(ctx, userId, idemKey, hash, rec.statusCode, rec.body.Bytes()); err != nil {
+				log.Printf("failed to complete idempotency key: %v", err)
+			}
+		})
+	}
+}
+
+// メソッド・パス・ボディが同じ場合のみ同じリクエストとみなす
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(r.URL.Path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// クライアントに書き込みつつ config.IdempotencyMaxResponseSize までレスポンスを保持する
+type responseRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+	overflow    bool
+}
+
+func (rec *responseRecorder) WriteHeader(statusCode int) {
+	if !rec.wroteHeader {
+		rec.statusCode = statusCode
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	if !rec.overflow {
+		if rec.body.Len()+len(b) > config.IdempotencyMaxResponseSize {
+			rec.overflow = true
+			rec.body.Reset()
+		} else {
+			rec.body.Write(b)
+		}
+	}
+	return rec.ResponseWriter.Write(b)
+}
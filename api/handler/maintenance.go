@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// メンテナンス中のレスポンス
+type MaintenanceResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// 終了予定時刻 (未定の場合は null)
+	EndsAt *time.Time `json:"ends_at"`
+}
+
+// クライアントは code でメンテナンス中かどうかを判断する
+const MaintenanceCode = "maintenance"
+
+// ヘルスチェックと管理 API はメンテナンス中・クライアントのバージョンに関わらず利用できる
+func isServiceGateExempt(r *http.Request) bool {
+	p := r.URL.Path
+	return p == "/health" || p == "/admin" || strings.HasPrefix(p, "/admin/")
+}
+
+//go:generate go run github.com/matryer/moq -out maintenance_moq_test.go . MaintenanceService
+type MaintenanceService interface {
+	CurrentMaintenance(ctx context.Context) (*entity.Maintenance, error)
+}
+
+// メンテナンス中は 503 を返す
+//
+// メンテナンスモードを取得できない場合は通常通り処理する
+func MaintenanceMiddleware(s MaintenanceService, c clock.Clocker) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isServiceGateExempt(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx := r.Context()
+			m, err := s.CurrentMaintenance(ctx)
+			if err != nil {
+				log.Printf("failed to get maintenance: %v", err)
+			}
+			if m == nil || !m.Enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if m.EndsAt != nil {
+				if seconds := int(math.Ceil(m.EndsAt.Sub(c.Now()).Seconds())); seconds > 0 {
+					w.Header().Set("Retry-After", strconv.Itoa(seconds))
+				}
+			}
+			RespondJson(ctx, w, MaintenanceResponse{
+				Code:    MaintenanceCode,
+				Message: m.Message,
+				EndsAt:  m.EndsAt,
+			}, http.StatusServiceUnavailable)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	t.Parallel()
+
+	endsAt := clock.FixedClocker{}.Now().Add(time.Hour)
+
+	type want struct {
+		status     int
+		retryAfter string
+		rspFile    string
+	}
+
+	tests := map[string]struct {
+		path        string
+		maintenance *entity.Maintenance
+		err         error
+		want        want
+	}{
+		"ok_maintenance": {
+			path: "/user/me",
+			maintenance: &entity.Maintenance{
+				Enabled: true,
+				Message: "under maintenance",
+				EndsAt:  &endsAt,
+			},
+			want: want{
+				status:     http.StatusServiceUnavailable,
+				retryAfter: "3600",
+				rspFile:    "testdata/maintenance/ok_maintenance/res.json.golden",
+			},
+		},
+		"ok_maintenance_no_ends_at": {
+			// 終了予定時刻が未定の場合は Retry-After を返さない
+			path: "/user/me",
+			maintenance: &entity.Maintenance{
+				Enabled: true,
+				Message: "under maintenance",
+			},
+			want: want{
+				status:  http.StatusServiceUnavailable,
+				rspFile: "testdata/maintenance/ok_maintenance_no_ends_at/res.json.golden",
+			},
+		},
+		"ok_disabled": {
+			path:        "/user/me",
+			maintenance: &entity.Maintenance{},
+			want: want{
+				status: http.StatusOK,
+			},
+		},
+		"ok_health": {
+			path: "/health",
+			maintenance: &entity.Maintenance{
+				Enabled: true,
+			},
+			want: want{
+				status: http.StatusOK,
+			},
+		},
+		"ok_admin": {
+			path: "/admin/maintenance",
+			maintenance: &entity.Maintenance{
+				Enabled: true,
+			},
+			want: want{
+				status: http.StatusOK,
+			},
+		},
+		"ok_not_admin_prefix": {
+			// /admin から始まるだけのパスは除外しない
+			path: "/administrator",
+			maintenance: &entity.Maintenance{
+				Enabled: true,
+				Message: "under maintenance",
+			},
+			want: want{
+				status:  http.StatusServiceUnavailable,
+				rspFile: "testdata/maintenance/ok_maintenance_no_ends_at/res.json.golden",
+			},
+		},
+		"ok_error_without_cache": {
+			// 取得できない場合は通常通り処理する
+			path: "/user/me",
+			err:  errors.New("error from mock"),
+			want: want{
+				status: http.StatusOK,
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			moq := &MaintenanceServiceMock{}
+			moq.CurrentMaintenanceFunc = func(_ context.Context) (*entity.Maintenance, error) {
+				return tt.maintenance, tt.err
+			}
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			MaintenanceMiddleware(moq, clock.FixedClocker{})(next).ServeHTTP(w, r)
+
+			rsp := w.Result()
+			if got := rsp.Header.Get("Retry-After"); got != tt.want.retryAfter {
+				t.Errorf("want Retry-After %q, but got %q", tt.want.retryAfter, got)
+			}
+			var body []byte
+			if tt.want.rspFile != "" {
+				body = testutil.LoadFile(t, tt.want.rspFile)
+			}
+			testutil.AssertResponse(t, rsp, tt.want.status, body)
+		})
+	}
+}
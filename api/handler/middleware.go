@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/pollenjp/gameserver-go/api/auth"
@@ -8,12 +9,61 @@ import (
 
 func AuthMiddleware(au *auth.Authorizer) func(next http.Handler) http.Handler {
 	// request の context に認証情報を埋め込む
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req, err := au.FillContext(r)
+			var banned *auth.ErrUserBanned
+			if errors.As(err, &banned) {
+				RespondJson(r.Context(), w, BannedResponse{
+					Message: "user banned",
+					Reason:  banned.Ban.Reason,
+					EndsAt:  banned.Ban.EndsAt,
+				}, http.StatusForbidden)
+				return
+			}
+			if err != nil {
+				// クライアントが再発行・再ログインを判断できるように理由を分ける
+				msg := "not find auth info"
+				switch {
+				case errors.Is(err, auth.ErrTokenExpired):
+					msg = "token expired"
+				case errors.Is(err, auth.ErrTokenRevoked):
+					msg = "token revoked"
+				case errors.Is(err, auth.ErrInvalidJWT):
+					msg = "invalid access token"
+				}
+				RespondJson(r.Context(), w, ErrResponse{
+					Message: msg,
+					Details: []string{err.Error()},
+				}, http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// Authorization header がある場合のみ認証情報を埋め込む
+// (認証なしでも利用できるが、認証した場合に結果が変わるエンドポイント向け)
+func OptionalAuthMiddleware(au *auth.Authorizer) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			AuthMiddleware(au)(next).ServeHTTP(w, r)
+		})
+	}
+}
+
+func AdminAuthMiddleware(au *auth.AdminAuthorizer) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			req, err := au.FillContext(r)
 			if err != nil {
 				RespondJson(r.Context(), w, ErrResponse{
-					Message: "not find auth info",
+					Message: "invalid admin credential",
 					Details: []string{err.Error()},
 				}, http.StatusUnauthorized)
 				return
@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/auth"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+// user.token に平文のトークンが残っている (一度も認証していない) ユーザー 1 人分の user・user_session テーブル
+//
+// 認証と管理 API の両方から同じデータを参照する
+type legacyTokenRepository struct {
+	// 使わないメソッド
+	service.AdminUserRepository
+
+	now time.Time
+
+	mu sync.Mutex
+	// 平文で保存されているトークン (置き換えた後はどのトークンにも一致しない)
+	userToken entity.UserTokenType
+	sessions  map[entity.UserTokenType]*entity.UserSession
+}
+
+func (r *legacyTokenRepository) newSession(token entity.UserTokenType) *entity.UserSession {
+	s := &entity.UserSession{
+		Id:        entity.UserSessionId(len(r.sessions) + 1),
+		UserId:    1,
+		Token:     token,
+		IssuedAt:  r.now,
+		ExpiresAt: r.now.Add(time.Hour),
+	}
+	r.sessions[token] = s
+	return s
+}
+
+func (r *legacyTokenRepository) GetUserSessionFromToken(
+	_ context.Context,
+	_ service.Queryer,
+	token entity.UserTokenType,
+) (*entity.UserSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.sessions[token]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return s, nil
+}
+
+func (r *legacyTokenRepository) MigrateLegacyUserToken(
+	_ context.Context,
+	_ service.QueryerAndExecer,
+	token entity.UserTokenType,
+) (*entity.UserSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if token != r.userToken {
+		return nil, fmt.Errorf("from fake: %w", sql.ErrNoRows)
+	}
+	return r.newSession(token), nil
+}
+
+func (r *legacyTokenRepository) GetActiveUserBan(
+	_ context.Context,
+	_ service.Queryer,
+	_ entity.UserId,
+	_ time.Time,
+) (*entity.UserBan, error) {
+	return nil, nil
+}
+
+func (r *legacyTokenRepository) GetUserFromId(
+	_ context.Context,
+	_ service.Queryer,
+	userId entity.UserId,
+) (*entity.User, error) {
+	return &entity.User{Id: userId, Name: "test", LeaderCardId: 1}, nil
+}
+
+func (r *legacyTokenRepository) RevokeAllUserSessions(
+	_ context.Context,
+	_ service.Execer,
+	_ entity.UserId,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, s := range r.sessions {
+		if s.RevokedAt == nil {
+			s.RevokedAt = &r.now
+		}
+	}
+	return nil
+}
+
+func (r *legacyTokenRepository) RotateUserToken(
+	_ context.Context,
+	_ service.Execer,
+	_ entity.UserId,
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.userToken = "rotated"
+	return nil
+}
+
+func (r *legacyTokenRepository) IssueUserSession(
+	_ context.Context,
+	_ service.Execer,
+	_ entity.UserId,
+) (*entity.UserSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.newSession("new"), nil
+}
+
+func (r *legacyTokenRepository) CreateAuditLog(
+	_ context.Context,
+	_ service.Execer,
+	_ *entity.AuditLog,
+) error {
+	return nil
+}
+
+// 管理 API でトークンを再発行した後は、漏洩した古いトークンで認証できない
+func TestAuthMiddlewareAfterResetUserToken(t *testing.T) {
+	t.Parallel()
+
+	const legacyToken = entity.UserTokenType("legacy")
+
+	tests := map[string]struct {
+		reset bool
+		want  int
+	}{
+		"ok_before_reset": {
+			reset: false,
+			want:  http.StatusOK,
+		},
+		"unauthorized_after_reset": {
+			reset: true,
+			want:  http.StatusUnauthorized,
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			c := clock.FixedClocker{}
+			repo := &legacyTokenRepository{
+				now:       c.Now(),
+				userToken: legacyToken,
+				sessions:  map[entity.UserTokenType]*entity.UserSession{},
+			}
+			db, _ := testutil.TxDB(t)
+			au := auth.NewAuthorizer(db, repo, c)
+			sut := AuthMiddleware(au)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			serve := func(token entity.UserTokenType) int {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest(http.MethodGet, "/user/me", nil)
+				r.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+				sut.ServeHTTP(w, r)
+				return w.Result().StatusCode
+			}
+
+			if tt.reset {
+				aus := &service.AdminUser{
+					DB:      db,
+					Repo:    repo,
+					Clocker: c,
+				}
+				ctx := service.SetAdminName(context.Background(), "admin")
+				session, err := aus.ResetUserToken(ctx, 1)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got := serve(session.Token); got != http.StatusOK {
+					t.Errorf("new token: want status %d, but got %d", http.StatusOK, got)
+				}
+			}
+
+			if got := serve(legacyToken); got != tt.want {
+				t.Errorf("legacy token: want status %d, but got %d", tt.want, got)
+			}
+		})
+	}
+}
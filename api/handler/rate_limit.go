@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pollenjp/gameserver-go/api/service"
+	"github.com/pollenjp/gameserver-go/api/store"
+)
+
+// 認証済みの場合はユーザー毎、それ以外はクライアントの IP 毎に制限する
+//
+// ユーザー毎に制限するには AuthMiddleware の内側で使う
+// trustedProxyHeader を指定した場合は未認証のリクエストの IP をそのヘッダーから取得する (config.Config.TrustedProxyHeader)
+func RateLimitMiddleware(limiter *store.RateLimiter, trustedProxyHeader string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ok, retryAfter := limiter.Allow(rateLimitKey(r, trustedProxyHeader))
+			if !ok {
+				seconds := int(math.Ceil(retryAfter.Seconds()))
+				if seconds < 1 {
+					seconds = 1
+				}
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+				RespondJson(r.Context(), w, ErrResponse{
+					Message: "too many requests",
+					Details: []string{fmt.Sprintf("retry after %d seconds", seconds)},
+				}, http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func rateLimitKey(r *http.Request, trustedProxyHeader string) string {
+	if userId, ok := service.GetUserId(r.Context()); ok {
+		return fmt.Sprintf("user:%d", userId)
+	}
+	return "ip:" + clientIP(r, trustedProxyHeader)
+}
+
+// X-Forwarded-For のように複数の IP を含む場合は最後 (信頼するプロキシが付与したもの) を使う
+// ヘッダーが無い・不正な場合は接続元の IP を使う
+func clientIP(r *http.Request, trustedProxyHeader string) string {
+	if trustedProxyHeader != "" {
+		if values := r.Header.Values(trustedProxyHeader); len(values) > 0 {
+			ips := strings.Split(values[len(values)-1], ",")
+			if ip := net.ParseIP(strings.TrimSpace(ips[len(ips)-1])); ip != nil {
+				return ip.String()
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+	"github.com/pollenjp/gameserver-go/api/store"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	t.Parallel()
+
+	type req struct {
+		remoteAddr string
+		// X-Forwarded-For ヘッダー
+		forwardedFor string
+		// 0 の場合は未認証
+		userId entity.UserId
+	}
+
+	tests := map[string]struct {
+		trustedProxyHeader string
+		reqs               []req
+		// 最後のリクエストのステータスコード
+		want int
+	}{
+		"ok_different_host": {
+			reqs: []req{
+				{remoteAddr: "192.0.2.1:1000"},
+				{remoteAddr: "192.0.2.2:1000"},
+			},
+			want: http.StatusOK,
+		},
+		"ok_different_user": {
+			reqs: []req{
+				{remoteAddr: "192.0.2.1:1000", userId: 1},
+				{remoteAddr: "192.0.2.1:1000", userId: 2},
+			},
+			want: http.StatusOK,
+		},
+		"too_many_requests_same_host": {
+			// 接続毎にポートが変わっても同じクライアントとして数える
+			reqs: []req{
+				{remoteAddr: "192.0.2.1:1000"},
+				{remoteAddr: "192.0.2.1:1001"},
+			},
+			want: http.StatusTooManyRequests,
+		},
+		"too_many_requests_same_host_ipv6": {
+			reqs: []req{
+				{remoteAddr: "[2001:db8::1]:1000"},
+				{remoteAddr: "[2001:db8::1]:1001"},
+			},
+			want: http.StatusTooManyRequests,
+		},
+		"ok_different_forwarded_client": {
+			// プロキシを経由する場合は接続元が同じでもクライアント毎に数える
+			trustedProxyHeader: "X-Forwarded-For",
+			reqs: []req{
+				{remoteAddr: "10.0.0.1:1000", forwardedFor: "192.0.2.1"},
+				{remoteAddr: "10.0.0.1:1000", forwardedFor: "192.0.2.2"},
+			},
+			want: http.StatusOK,
+		},
+		"too_many_requests_same_forwarded_client": {
+			// 最後に付与された IP を使う (クライアントが先頭に付けた値は無視する)
+			trustedProxyHeader: "X-Forwarded-For",
+			reqs: []req{
+				{remoteAddr: "10.0.0.1:1000", forwardedFor: "198.51.100.1, 192.0.2.1"},
+				{remoteAddr: "10.0.0.1:1001", forwardedFor: "198.51.100.2, 192.0.2.1"},
+			},
+			want: http.StatusTooManyRequests,
+		},
+		"too_many_requests_untrusted_header": {
+			// ヘッダーを信頼しない場合は偽装されても接続元の IP で数える
+			reqs: []req{
+				{remoteAddr: "192.0.2.1:1000", forwardedFor: "198.51.100.1"},
+				{remoteAddr: "192.0.2.1:1001", forwardedFor: "198.51.100.2"},
+			},
+			want: http.StatusTooManyRequests,
+		},
+		"too_many_requests_same_user": {
+			// 認証済みの場合は IP が変わっても同じユーザーとして数える
+			reqs: []req{
+				{remoteAddr: "192.0.2.1:1000", userId: 1},
+				{remoteAddr: "192.0.2.2:1000", userId: 1},
+			},
+			want: http.StatusTooManyRequests,
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			limiter := store.NewRateLimiter(clock.FixedClocker{}, time.Minute, 1)
+			next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+			sut := RateLimitMiddleware(limiter, tt.trustedProxyHeader)(next)
+
+			var got int
+			for _, q := range tt.reqs {
+				w := httptest.NewRecorder()
+				r := httptest.NewRequest(http.MethodGet, "/user/me", nil)
+				r.RemoteAddr = q.remoteAddr
+				if q.forwardedFor != "" {
+					r.Header.Set("X-Forwarded-For", q.forwardedFor)
+				}
+				if q.userId != 0 {
+					r = r.WithContext(service.SetUserId(r.Context(), q.userId))
+				}
+				sut.ServeHTTP(w, r)
+				got = w.Result().StatusCode
+			}
+			if got != tt.want {
+				t.Errorf("want status %d, but got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+const RequestIdHeader = "X-Request-Id"
+
+// 監査ログに記録できない値はクライアントから受け取らない
+var validRequestId = regexp.MustCompile(`^[0-9A-Za-z_.-]{1,64}$`)
+
+// X-Request-Id を context に埋め込み、レスポンスにも返す (無い場合は生成する)
+func RequestIdMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// `/admin` をマウントした場合などに二重に設定しない
+		if _, ok := service.GetRequestId(r.Context()); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		rid := r.Header.Get(RequestIdHeader)
+		if !validRequestId.MatchString(rid) {
+			rid = uuid.NewString()
+		}
+		w.Header().Set(RequestIdHeader, rid)
+		next.ServeHTTP(w, r.WithContext(service.SetRequestId(r.Context(), rid)))
+	})
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/pollenjp/gameserver-go/api/auth"
+)
+
+// paths に含まれるパスへのリクエストのみ署名を必須にする
+func RequestSignatureMiddleware(s *auth.RequestSigner, paths []string) func(next http.Handler) http.Handler {
+	signed := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		signed[p] = true
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !signed[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx := r.Context()
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				RespondJson(ctx, w, ErrResponse{
+					Message: err.Error(),
+				}, http.StatusInternalServerError)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			if err := s.Verify(r, body); err != nil {
+				// クライアントが時刻のずれ・nonce の再利用を判断できるように理由を分ける
+				msg := "invalid request signature"
+				switch {
+				case errors.Is(err, auth.ErrSignatureMissing):
+					msg = "request signature required"
+				case errors.Is(err, auth.ErrSignatureExpired):
+					msg = "request signature expired"
+				case errors.Is(err, auth.ErrSignatureReplayed):
+					msg = "request signature replayed"
+				}
+				RespondJson(ctx, w, ErrResponse{
+					Message: msg,
+					Details: []string{err.Error()},
+				}, http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
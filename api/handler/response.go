@@ -5,11 +5,29 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type ErrResponse struct {
 	Message string   `json:"message"`
 	Details []string `json:"details"`
+	// リクエストのフィールド毎の検証エラー
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+type FieldError struct {
+	// リクエストの JSON のキー
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// 利用停止中のユーザーへのレスポンス
+type BannedResponse struct {
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+	// 無期限の場合は null
+	EndsAt *time.Time `json:"ends_at"`
 }
 
 // RespondJson is a helper function to write JSON response.
@@ -0,0 +1,89 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+//go:generate go run github.com/matryer/moq -out accept_room_invitation_moq_test.go . AcceptRoomInvitationService
+type AcceptRoomInvitationService interface {
+	AcceptRoomInvitation(
+		ctx context.Context,
+		invitationId entity.RoomInvitationId,
+		userId entity.UserId,
+		liveDifficulty entity.LiveDifficulty,
+	) (entity.JoinRoomResult, error)
+}
+
+type AcceptRoomInvitation struct {
+	Service   AcceptRoomInvitationService
+	Validator *validator.Validate
+}
+
+func (ru *AcceptRoomInvitation) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		InvitationId     entity.RoomInvitationId `json:"invitation_id" validate:"required"`
+		SelectDifficulty entity.LiveDifficulty   `json:"select_difficulty" validate:"required"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := ru.Service.AcceptRoomInvitation(
+		ctx,
+		body.InvitationId,
+		userId,
+		body.SelectDifficulty,
+	)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.As(err, new(*entity.ErrAlreadyInRoom)),
+			errors.As(err, new(*entity.ErrInvitationAnswered)):
+			status = http.StatusConflict
+		case errors.As(err, new(*entity.ErrInvitationExpired)):
+			status = http.StatusGone
+		case errors.As(err, new(*entity.ErrPermissionDenied)):
+			status = http.StatusForbidden
+		case errors.As(err, new(*entity.ErrNotFound)):
+			status = http.StatusNotFound
+		}
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, status)
+		return
+	}
+
+	rsp := JoinRoomResponseJson{
+		JoinRoomResult: result,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,79 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out change_room_live_moq_test.go . ChangeRoomLiveService
+type ChangeRoomLiveService interface {
+	ChangeRoomLive(
+		ctx context.Context,
+		roomId entity.RoomId,
+		hostUserId entity.UserId,
+		liveId entity.LiveId,
+		hostLiveDifficulty entity.LiveDifficulty,
+	) error
+}
+
+type ChangeRoomLive struct {
+	Service   ChangeRoomLiveService
+	Validator *validator.Validate
+}
+
+type ChangeRoomLiveRequestJson struct {
+	RoomId           entity.RoomId         `json:"room_id" validate:"required"`
+	LiveId           entity.LiveId         `json:"live_id" validate:"required"`
+	SelectDifficulty entity.LiveDifficulty `json:"select_difficulty" validate:"required"`
+}
+
+func (ru *ChangeRoomLive) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body ChangeRoomLiveRequestJson
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Service.ChangeRoomLive(
+		ctx,
+		body.RoomId,
+		userId,
+		body.LiveId,
+		body.SelectDifficulty,
+	); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	rsp := struct{}{}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -3,6 +3,7 @@ package room
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -18,6 +19,7 @@ type CreateRoomService interface {
 	CreateRoom(
 		ctx context.Context,
 		liveId entity.LiveId,
+		setlist []entity.LiveId,
 		hostUserId entity.UserId,
 	) (*entity.Room, *entity.RoomUser, error)
 }
@@ -31,6 +33,8 @@ type CreateRoomRequestJson struct {
 	// create room request は Live ID が 1 以上の必要がある (-> `validate:"required"`)
 	LiveId           entity.LiveId         `json:"live_id" validate:"required"`
 	SelectDifficulty entity.LiveDifficulty `json:"select_difficulty" validate:"required"`
+	// 2 ラウンド目以降に演奏する楽曲 (省略可、最大 20 曲)
+	Setlist []entity.LiveId `json:"setlist,omitempty" validate:"max=20,dive,required"`
 }
 
 type CreateRoomResponseJson struct {
@@ -62,11 +66,15 @@ func (ru *CreateRoom) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	room, _, err := ru.Service.CreateRoom(ctx, body.LiveId, userId)
+	room, _, err := ru.Service.CreateRoom(ctx, body.LiveId, body.Setlist, userId)
 	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.As(err, new(*entity.ErrAlreadyInRoom)) {
+			status = http.StatusConflict
+		}
 		handler.RespondJson(ctx, w, &handler.ErrResponse{
 			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		}, status)
 		return
 	}
 
@@ -0,0 +1,80 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+//go:generate go run github.com/matryer/moq -out decline_room_invitation_moq_test.go . DeclineRoomInvitationService
+type DeclineRoomInvitationService interface {
+	DeclineRoomInvitation(
+		ctx context.Context,
+		invitationId entity.RoomInvitationId,
+		userId entity.UserId,
+	) error
+}
+
+type DeclineRoomInvitation struct {
+	Service   DeclineRoomInvitationService
+	Validator *validator.Validate
+}
+
+func (ru *DeclineRoomInvitation) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		InvitationId entity.RoomInvitationId `json:"invitation_id" validate:"required"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Service.DeclineRoomInvitation(
+		ctx,
+		body.InvitationId,
+		userId,
+	); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.As(err, new(*entity.ErrInvitationAnswered)):
+			status = http.StatusConflict
+		case errors.As(err, new(*entity.ErrPermissionDenied)):
+			status = http.StatusForbidden
+		case errors.As(err, new(*entity.ErrNotFound)):
+			status = http.StatusNotFound
+		}
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, status)
+		return
+	}
+
+	rsp := struct{}{}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -3,6 +3,7 @@ package room
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -20,6 +21,7 @@ type JoinRoomService interface {
 		roomId entity.RoomId,
 		userId entity.UserId,
 		liveDifficulty entity.LiveDifficulty,
+		asSpectator bool,
 	) (entity.JoinRoomResult, error)
 }
 
@@ -37,6 +39,8 @@ func (ru *JoinRoom) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var body struct {
 		RoomId           entity.RoomId         `json:"room_id" validate:"required"`
 		SelectDifficulty entity.LiveDifficulty `json:"select_difficulty" validate:"required"`
+		// 観戦者として参加する
+		AsSpectator bool `json:"as_spectator"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -66,11 +70,16 @@ func (ru *JoinRoom) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		body.RoomId,
 		userId,
 		body.SelectDifficulty,
+		body.AsSpectator,
 	)
 	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.As(err, new(*entity.ErrAlreadyInRoom)) {
+			status = http.StatusConflict
+		}
 		handler.RespondJson(ctx, w, &handler.ErrResponse{
 			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		}, status)
 		return
 	}
 
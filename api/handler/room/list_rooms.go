@@ -3,6 +3,7 @@ package room
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -19,6 +20,7 @@ type GetRoomListService interface {
 	GetRoomList(
 		ctx context.Context,
 		LiveId entity.LiveId,
+		friendOnly bool,
 	) ([]*service.RoomInfoItem, error)
 }
 
@@ -43,6 +45,8 @@ func (ru *GetRoomList) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var body struct {
 		// 0 の可能性があるので `validate:"required"` はつけない
 		LiveId entity.LiveId `json:"live_id"`
+		// フレンドが host または参加しているルームのみ (要認証)
+		FriendOnly bool `json:"friend_only"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -63,11 +67,15 @@ func (ru *GetRoomList) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rooms, err := ru.Service.GetRoomList(ctx, body.LiveId)
+	rooms, err := ru.Service.GetRoomList(ctx, body.LiveId, body.FriendOnly)
 	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.As(err, new(*entity.ErrUnauthorized)) {
+			status = http.StatusUnauthorized
+		}
 		handler.RespondJson(ctx, w, &handler.ErrResponse{
 			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		}, status)
 		return
 	}
 
@@ -0,0 +1,74 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out live_progress_moq_test.go . LiveProgressService
+type LiveProgressService interface {
+	GetLiveProgress(
+		ctx context.Context,
+		roomId entity.RoomId,
+		userId entity.UserId,
+	) (*service.LiveProgressResult, error)
+}
+
+type LiveProgress struct {
+	Service   LiveProgressService
+	Validator *validator.Validate
+}
+
+func (ru *LiveProgress) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		RoomId entity.RoomId `json:"room_id" validate:"required"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := ru.Service.GetLiveProgress(ctx, body.RoomId, userId)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	rsp := struct {
+		Round        int                    `json:"round"`
+		ProgressList []*entity.LiveProgress `json:"progress_list"`
+	}{
+		Round:        result.Round,
+		ProgressList: result.ProgressList,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,84 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out next_round_moq_test.go . NextRoundService
+type NextRoundService interface {
+	NextRound(
+		ctx context.Context,
+		roomId entity.RoomId,
+		hostUserId entity.UserId,
+		liveId entity.LiveId,
+	) (*entity.Room, error)
+}
+
+type NextRound struct {
+	Service   NextRoundService
+	Validator *validator.Validate
+}
+
+type NextRoundResponseJson struct {
+	Round  int           `json:"round"`
+	LiveId entity.LiveId `json:"live_id"`
+}
+
+func (ru *NextRound) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		RoomId entity.RoomId `json:"room_id" validate:"required"`
+		// 0 (省略時) はセットリストの次の楽曲、セットリストが無ければ同じ楽曲
+		LiveId entity.LiveId `json:"live_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	room, err := ru.Service.NextRound(
+		ctx,
+		body.RoomId,
+		userId,
+		body.LiveId,
+	)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	rsp := NextRoundResponseJson{
+		Round:  room.Round,
+		LiveId: room.LiveId,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
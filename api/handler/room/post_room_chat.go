@@ -0,0 +1,125 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+	"github.com/pollenjp/gameserver-go/api/textpolicy"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out post_room_chat_moq_test.go . PostRoomChatService
+type PostRoomChatService interface {
+	PostRoomChat(
+		ctx context.Context,
+		chat *entity.RoomChat,
+	) (*entity.RoomChat, error)
+}
+
+type PostRoomChat struct {
+	Service PostRoomChatService
+	// nil の場合はメッセージを正規化せず、文字 (rune) 数のみを config.ChatMessageMaxLength で検証する
+	MessagePolicy *textpolicy.Policy
+	Validator     *validator.Validate
+}
+
+type RoomChatJson struct {
+	Id        entity.RoomChatId `json:"id"`
+	UserId    entity.UserId     `json:"user_id"`
+	UserName  string            `json:"user_name"`
+	Message   string            `json:"message"`
+	StampId   entity.StampId    `json:"stamp_id"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func NewRoomChatJson(chat *entity.RoomChat) *RoomChatJson {
+	return &RoomChatJson{
+		Id:        chat.Id,
+		UserId:    chat.UserId,
+		UserName:  chat.UserName,
+		Message:   chat.Message,
+		StampId:   chat.StampId,
+		CreatedAt: chat.CreatedAt,
+	}
+}
+
+func (ru *PostRoomChat) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	// message と stamp_id のどちらか一方のみを指定する
+	// - message: MessagePolicy で検証する (max は DB のカラムの長さ、書記素クラスタで数える MessagePolicy は rune 数がより多くなり得るため)
+	// - stamp_id: 1 ~ 32
+	var body struct {
+		RoomId  entity.RoomId  `json:"room_id" validate:"required"`
+		Message string         `json:"message" validate:"required_without=StampId,excluded_with=StampId,max=255"`
+		StampId entity.StampId `json:"stamp_id" validate:"required_without=Message,omitempty,min=1,max=32"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if ru.MessagePolicy != nil && body.Message != "" {
+		message, ok := handler.CheckTextField(ctx, w, ru.MessagePolicy, "message", body.Message)
+		if !ok {
+			return
+		}
+		body.Message = message
+	} else if utf8.RuneCountInString(body.Message) > config.ChatMessageMaxLength {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("message must be at most %d characters", config.ChatMessageMaxLength),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	chat, err := ru.Service.PostRoomChat(ctx, &entity.RoomChat{
+		RoomId:  body.RoomId,
+		UserId:  userId,
+		Message: body.Message,
+		StampId: body.StampId,
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.As(err, new(*entity.ErrTooManyRequests)) {
+			status = http.StatusTooManyRequests
+		}
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, status)
+		return
+	}
+
+	rsp := struct {
+		Id entity.RoomChatId `json:"id"`
+	}{
+		Id: chat.Id,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
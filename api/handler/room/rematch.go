@@ -0,0 +1,88 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out rematch_moq_test.go . RematchService
+type RematchService interface {
+	Rematch(
+		ctx context.Context,
+		roomId entity.RoomId,
+		hostUserId entity.UserId,
+		liveId entity.LiveId,
+		liveDifficulty entity.LiveDifficulty,
+	) (*entity.Room, error)
+}
+
+type Rematch struct {
+	Service   RematchService
+	Validator *validator.Validate
+}
+
+type RematchRequestJson struct {
+	RoomId entity.RoomId `json:"room_id" validate:"required"`
+	// 0 (省略時) は同じ楽曲
+	LiveId           entity.LiveId         `json:"live_id"`
+	SelectDifficulty entity.LiveDifficulty `json:"select_difficulty" validate:"required"`
+}
+
+func (ru *Rematch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body RematchRequestJson
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	room, err := ru.Service.Rematch(
+		ctx,
+		body.RoomId,
+		userId,
+		body.LiveId,
+		body.SelectDifficulty,
+	)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.As(err, new(*entity.ErrAlreadyInRoom)) {
+			status = http.StatusConflict
+		}
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, status)
+		return
+	}
+
+	rsp := CreateRoomResponseJson{
+		RoomId: room.Id,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
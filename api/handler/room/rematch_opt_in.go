@@ -0,0 +1,79 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out rematch_opt_in_moq_test.go . RematchOptInService
+type RematchOptInService interface {
+	RematchOptIn(
+		ctx context.Context,
+		roomId entity.RoomId,
+		userId entity.UserId,
+		rematch bool,
+	) error
+}
+
+type RematchOptIn struct {
+	Service   RematchOptInService
+	Validator *validator.Validate
+}
+
+func (ru *RematchOptIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		RoomId  entity.RoomId `json:"room_id" validate:"required"`
+		Rematch bool          `json:"rematch"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Service.RematchOptIn(
+		ctx,
+		body.RoomId,
+		userId,
+		body.Rematch,
+	); err != nil {
+		status := http.StatusInternalServerError
+		if errors.As(err, new(*entity.ErrPermissionDenied)) {
+			status = http.StatusForbidden
+		}
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, status)
+		return
+	}
+
+	rsp := struct{}{}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
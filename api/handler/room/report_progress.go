@@ -0,0 +1,78 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out report_progress_moq_test.go . ReportProgressService
+type ReportProgressService interface {
+	ReportProgress(
+		ctx context.Context,
+		roomId entity.RoomId,
+		progress *entity.LiveProgress,
+	) error
+}
+
+type ReportProgress struct {
+	Service   ReportProgressService
+	Validator *validator.Validate
+}
+
+func (ru *ReportProgress) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		RoomId entity.RoomId `json:"room_id" validate:"required"`
+		// ライブ開始直後は 0 の可能性があるので `validate:"required"` はつけない
+		Score int `json:"score" validate:"min=0"`
+		Combo int `json:"combo" validate:"min=0"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Service.ReportProgress(
+		ctx,
+		body.RoomId,
+		&entity.LiveProgress{
+			UserId: userId,
+			Score:  body.Score,
+			Combo:  body.Combo,
+		},
+	); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	rsp := struct{}{}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
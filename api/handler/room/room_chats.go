@@ -0,0 +1,101 @@
+package room
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out room_chats_moq_test.go . RoomChatsService
+type RoomChatsService interface {
+	GetRoomChats(
+		ctx context.Context,
+		roomId entity.RoomId,
+		userId entity.UserId,
+		sinceId entity.RoomChatId,
+	) ([]*entity.RoomChat, error)
+}
+
+type RoomChats struct {
+	Service   RoomChatsService
+	Validator *validator.Validate
+}
+
+// GET /room/chat?room_id=<room_id>&since=<chat_id>
+func (ru *RoomChats) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var query struct {
+		RoomId entity.RoomId `validate:"required"`
+		// 0 (省略時) は最初から
+		Since entity.RoomChatId `validate:"min=0"`
+	}
+
+	q := r.URL.Query()
+	for _, p := range []struct {
+		key string
+		dst *int64
+	}{
+		{key: "room_id", dst: (*int64)(&query.RoomId)},
+		{key: "since", dst: (*int64)(&query.Since)},
+	} {
+		v := q.Get(p.key)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			handler.RespondJson(ctx, w, &handler.ErrResponse{
+				Message: "invalid query parameter: " + p.key,
+				Details: []string{err.Error()},
+			}, http.StatusBadRequest)
+			return
+		}
+		*p.dst = n
+	}
+
+	if err := ru.Validator.Struct(query); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	chats, err := ru.Service.GetRoomChats(ctx, query.RoomId, userId, query.Since)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	nextSince := query.Since
+	chatList := make([]*RoomChatJson, len(chats))
+	for i, chat := range chats {
+		chatList[i] = NewRoomChatJson(chat)
+		nextSince = chat.Id
+	}
+
+	rsp := struct {
+		ChatList []*RoomChatJson `json:"chat_list"`
+		// 次回の `since` に指定する値
+		NextSince entity.RoomChatId `json:"next_since"`
+	}{
+		ChatList:  chatList,
+		NextSince: nextSince,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,203 @@
+package room
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestAcceptRoomInvitation(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+
+	tests := map[string]struct {
+		reqFile string
+		// サービスが返す結果・エラー
+		result entity.JoinRoomResult
+		err    error
+		want   want
+	}{
+		"ok": {
+			reqFile: "testdata/accept_room_invitation/ok/req.json.golden",
+			result:  entity.JoinRoomResultOk,
+			want: want{
+				status:  200, // http.StatusOK
+				rspFile: "testdata/accept_room_invitation/ok/res.json.golden",
+			},
+		},
+		"ok_room_full": {
+			reqFile: "testdata/accept_room_invitation/ok_room_full/req.json.golden",
+			result:  entity.JoinRoomResultRoomFull,
+			want: want{
+				status:  200, // http.StatusOK
+				rspFile: "testdata/accept_room_invitation/ok_room_full/res.json.golden",
+			},
+		},
+		"expired": {
+			// サーバーのエラーと区別できるようにする
+			reqFile: "testdata/accept_room_invitation/expired/req.json.golden",
+			err:     &entity.ErrInvitationExpired{},
+			want: want{
+				status:  410, // http.StatusGone
+				rspFile: "testdata/accept_room_invitation/expired/res.json.golden",
+			},
+		},
+		"answered": {
+			reqFile: "testdata/accept_room_invitation/answered/req.json.golden",
+			err:     &entity.ErrInvitationAnswered{},
+			want: want{
+				status:  409, // http.StatusConflict
+				rspFile: "testdata/accept_room_invitation/answered/res.json.golden",
+			},
+		},
+		"other_user": {
+			reqFile: "testdata/accept_room_invitation/other_user/req.json.golden",
+			err:     &entity.ErrPermissionDenied{},
+			want: want{
+				status:  403, // http.StatusForbidden
+				rspFile: "testdata/accept_room_invitation/other_user/res.json.golden",
+			},
+		},
+		"not_found": {
+			reqFile: "testdata/accept_room_invitation/not_found/req.json.golden",
+			err:     &entity.ErrNotFound{},
+			want: want{
+				status:  404, // http.StatusNotFound
+				rspFile: "testdata/accept_room_invitation/not_found/res.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/room/invitation/accept",
+				bytes.NewReader(testutil.LoadFile(t, tt.reqFile)),
+			)
+			r = r.WithContext(service.SetUserId(r.Context(), 2))
+
+			moq := &AcceptRoomInvitationServiceMock{}
+			moq.AcceptRoomInvitationFunc = func(
+				_ context.Context,
+				_ entity.RoomInvitationId,
+				_ entity.UserId,
+				_ entity.LiveDifficulty,
+			) (entity.JoinRoomResult, error) {
+				if tt.err != nil {
+					return entity.JoinRoomResultOtherErr, fmt.Errorf("AcceptRoomInvitation: %w", tt.err)
+				}
+				return tt.result, nil
+			}
+			sut := AcceptRoomInvitation{
+				Service:   moq,
+				Validator: validator.New(),
+			}
+			sut.ServeHTTP(w, r)
+
+			rsp := w.Result()
+			testutil.AssertResponse(
+				t,
+				rsp,
+				tt.want.status,
+				testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
+
+func TestDeclineRoomInvitation(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+
+	tests := map[string]struct {
+		reqFile string
+		// サービスが返すエラー
+		err  error
+		want want
+	}{
+		"ok": {
+			reqFile: "testdata/decline_room_invitation/ok/req.json.golden",
+			want: want{
+				status:  200, // http.StatusOK
+				rspFile: "testdata/decline_room_invitation/ok/res.json.golden",
+			},
+		},
+		"answered": {
+			reqFile: "testdata/decline_room_invitation/answered/req.json.golden",
+			err:     &entity.ErrInvitationAnswered{},
+			want: want{
+				status:  409, // http.StatusConflict
+				rspFile: "testdata/decline_room_invitation/answered/res.json.golden",
+			},
+		},
+		"other_user": {
+			reqFile: "testdata/decline_room_invitation/other_user/req.json.golden",
+			err:     &entity.ErrPermissionDenied{},
+			want: want{
+				status:  403, // http.StatusForbidden
+				rspFile: "testdata/decline_room_invitation/other_user/res.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/room/invitation/decline",
+				bytes.NewReader(testutil.LoadFile(t, tt.reqFile)),
+			)
+			r = r.WithContext(service.SetUserId(r.Context(), 2))
+
+			moq := &DeclineRoomInvitationServiceMock{}
+			moq.DeclineRoomInvitationFunc = func(
+				_ context.Context,
+				_ entity.RoomInvitationId,
+				_ entity.UserId,
+			) error {
+				if tt.err != nil {
+					return fmt.Errorf("DeclineRoomInvitation: %w", tt.err)
+				}
+				return nil
+			}
+			sut := DeclineRoomInvitation{
+				Service:   moq,
+				Validator: validator.New(),
+			}
+			sut.ServeHTTP(w, r)
+
+			rsp := w.Result()
+			testutil.AssertResponse(
+				t,
+				rsp,
+				tt.want.status,
+				testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package room
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out room_invitations_moq_test.go . RoomInvitationsService
+type RoomInvitationsService interface {
+	GetRoomInvitations(
+		ctx context.Context,
+		userId entity.UserId,
+	) ([]*service.RoomInvitationItem, error)
+}
+
+type RoomInvitations struct {
+	Service   RoomInvitationsService
+	Validator *validator.Validate
+}
+
+type RoomInvitationResponseJsonItem struct {
+	InvitationId  entity.RoomInvitationId `json:"invitation_id"`
+	RoomId        entity.RoomId           `json:"room_id"`
+	LiveId        entity.LiveId           `json:"live_id"`
+	InviterUserId entity.UserId           `json:"inviter_user_id"`
+	InviterName   string                  `json:"inviter_name"`
+	ExpiresAt     time.Time               `json:"expires_at"`
+}
+
+func (ru *RoomInvitations) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	invitations, err := ru.Service.GetRoomInvitations(ctx, userId)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	invitationList := make([]*RoomInvitationResponseJsonItem, len(invitations))
+	for i, inv := range invitations {
+		invitationList[i] = &RoomInvitationResponseJsonItem{
+			InvitationId:  inv.InvitationId,
+			RoomId:        inv.RoomId,
+			LiveId:        inv.LiveId,
+			InviterUserId: inv.InviterUserId,
+			InviterName:   inv.InviterName,
+			ExpiresAt:     inv.ExpiresAt,
+		}
+	}
+
+	rsp := struct {
+		InvitationList []*RoomInvitationResponseJsonItem `json:"invitation_list"`
+	}{
+		InvitationList: invitationList,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -18,6 +18,7 @@ type RoomResultService interface {
 	GetRoomResult(
 		ctx context.Context,
 		roomId entity.RoomId,
+		round int,
 	) (service.RoomUserResultList, error)
 }
 
@@ -30,6 +31,8 @@ func (ru *RoomResult) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	var body struct {
 		RoomId entity.RoomId `json:"room_id" validate:"required"`
+		// 0 (省略時) は現在のラウンド
+		Round int `json:"round" validate:"min=0"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
@@ -50,7 +53,7 @@ func (ru *RoomResult) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	roomUserResults, err := ru.Service.GetRoomResult(ctx, body.RoomId)
+	roomUserResults, err := ru.Service.GetRoomResult(ctx, body.RoomId, body.Round)
 	if err != nil {
 		handler.RespondJson(ctx, w, &handler.ErrResponse{
 			Message: err.Error(),
@@ -0,0 +1,85 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out room_standings_moq_test.go . RoomStandingsService
+type RoomStandingsService interface {
+	GetRoomStandings(
+		ctx context.Context,
+		roomId entity.RoomId,
+	) ([]*service.RoomStanding, error)
+}
+
+type RoomStandings struct {
+	Service   RoomStandingsService
+	Validator *validator.Validate
+}
+
+func (ru *RoomStandings) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		RoomId entity.RoomId `json:"room_id" validate:"required"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	standings, err := ru.Service.GetRoomStandings(ctx, body.RoomId)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	type item struct {
+		Rank         int           `json:"rank"`
+		UserId       entity.UserId `json:"user_id"`
+		TotalScore   int           `json:"total_score"`
+		PlayedRounds int           `json:"played_rounds"`
+	}
+
+	// 同点の場合は同じ順位にする
+	standingList := make([]*item, len(standings))
+	for i, standing := range standings {
+		rank := i + 1
+		if i > 0 && standings[i-1].TotalScore == standing.TotalScore {
+			rank = standingList[i-1].Rank
+		}
+		standingList[i] = &item{
+			Rank:         rank,
+			UserId:       standing.UserId,
+			TotalScore:   standing.TotalScore,
+			PlayedRounds: standing.PlayedRounds,
+		}
+	}
+
+	rsp := struct {
+		StandingList []*item `json:"standing_list"`
+	}{
+		StandingList: standingList,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/pollenjp/gameserver-go/api/entity"
@@ -19,7 +20,7 @@ type StartRoomService interface {
 		ctx context.Context,
 		roomId entity.RoomId,
 		userId entity.UserId,
-	) error
+	) (time.Time, error)
 }
 
 type StartRoom struct {
@@ -55,17 +56,22 @@ func (ru *StartRoom) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := ru.Service.StartRoom(
+	startAt, err := ru.Service.StartRoom(
 		ctx,
 		body.RoomId,
 		userId,
-	); err != nil {
+	)
+	if err != nil {
 		handler.RespondJson(ctx, w, &handler.ErrResponse{
 			Message: err.Error(),
 		}, http.StatusInternalServerError)
 		return
 	}
 
-	rsp := struct{}{}
+	rsp := struct {
+		StartAt time.Time `json:"start_at"`
+	}{
+		StartAt: startAt,
+	}
 	handler.RespondJson(ctx, w, rsp, http.StatusOK)
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/pollenjp/gameserver-go/api/entity"
@@ -68,11 +69,23 @@ func (wr *WaitRoom) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	rsp := struct {
-		Status       entity.RoomStatus          `json:"status"`
+		Status entity.RoomStatus `json:"status"`
+		LiveId entity.LiveId     `json:"live_id"`
+		Round  int               `json:"round"`
+		// `/room/rematch` で作成されたルーム (0 は未作成)
+		RematchRoomId entity.RoomId `json:"rematch_room_id"`
+		// ライブの開始時刻 (LiveStart になるまでは null)
+		StartAt      *time.Time                 `json:"start_at"`
+		UpdatedAt    time.Time                  `json:"updated_at"`
 		RoomInfoList []*service.WaitingRoomUser `json:"room_user_list"`
 	}{
-		Status:       waitRoomResult.Room.Status,
-		RoomInfoList: waitRoomResult.WaitingRoomUser,
+		Status:        waitRoomResult.Room.Status,
+		LiveId:        waitRoomResult.Room.LiveId,
+		Round:         waitRoomResult.Room.Round,
+		RematchRoomId: waitRoomResult.Room.RematchRoomId,
+		StartAt:       waitRoomResult.Room.StartAt,
+		UpdatedAt:     waitRoomResult.Room.UpdatedAt,
+		RoomInfoList:  waitRoomResult.WaitingRoomUser,
 	}
 	handler.RespondJson(ctx, w, rsp, http.StatusOK)
 }
@@ -0,0 +1,36 @@
+package system
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/handler"
+)
+
+// NTP と同様にクライアントが時刻のずれを推定するためのエンドポイント
+//
+// クライアントは送信時刻 t0 と受信時刻 t3 を記録し、以下で offset を推定する
+//
+//	offset = ((receive_time - t0) + (transmit_time - t3)) / 2
+type ServerTime struct {
+	Clocker clock.Clocker
+}
+
+type ServerTimeResponseJson struct {
+	// サーバーがリクエストを受信した時刻
+	ReceiveTime time.Time `json:"receive_time"`
+	// サーバーがレスポンスを送信した時刻
+	TransmitTime time.Time `json:"transmit_time"`
+}
+
+func (st *ServerTime) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	receiveTime := st.Clocker.Now()
+	ctx := r.Context()
+
+	rsp := ServerTimeResponseJson{
+		ReceiveTime:  receiveTime,
+		TransmitTime: st.Clocker.Now(),
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
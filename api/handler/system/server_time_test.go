@@ -0,0 +1,57 @@
+package system
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestServerTime(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+
+	tests := map[string]struct {
+		want want
+	}{
+		"ok": {
+			want: want{
+				status:  200, // http.StatusOK
+				rspFile: "testdata/server_time/ok/res.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodGet,
+				"/time",
+				nil,
+			)
+
+			sut := ServerTime{
+				Clocker: clock.FixedClocker{},
+			}
+			sut.ServeHTTP(w, r)
+
+			rsp := w.Result()
+			testutil.AssertResponse(
+				t,
+				rsp,
+				tt.want.status,
+				testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pollenjp/gameserver-go/api/textpolicy"
+)
+
+// policy で value を検証し、正規化した文字列を返す
+//
+// 検証に失敗した場合はフィールド毎のエラーを 400 で返し、false を返す
+func CheckTextField(
+	ctx context.Context,
+	w http.ResponseWriter,
+	policy *textpolicy.Policy,
+	field string,
+	value string,
+) (string, bool) {
+	normalized, violations := policy.Check(value)
+	if len(violations) == 0 {
+		return normalized, true
+	}
+	fields := make([]FieldError, 0, len(violations))
+	for _, v := range violations {
+		fields = append(fields, FieldError{
+			Field:   field,
+			Code:    v.Code,
+			Message: v.Message,
+		})
+	}
+	RespondJson(ctx, w, ErrResponse{
+		Message: "invalid " + field,
+		Fields:  fields,
+	}, http.StatusBadRequest)
+	return "", false
+}
@@ -0,0 +1,67 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out block_list_moq_test.go . BlockListService
+type BlockListService interface {
+	GetBlockList(
+		ctx context.Context,
+		userId entity.UserId,
+	) ([]*service.BlockListItem, error)
+}
+
+type BlockList struct {
+	Service   BlockListService
+	Validator *validator.Validate
+}
+
+type BlockListResponseJsonItem struct {
+	UserId    entity.UserId `json:"user_id"`
+	Name      string        `json:"name"`
+	BlockedAt time.Time     `json:"blocked_at"`
+}
+
+func (ru *BlockList) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	blocks, err := ru.Service.GetBlockList(ctx, userId)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	blockList := make([]*BlockListResponseJsonItem, len(blocks))
+	for i, b := range blocks {
+		blockList[i] = &BlockListResponseJsonItem{
+			UserId:    b.UserId,
+			Name:      b.Name,
+			BlockedAt: b.BlockedAt,
+		}
+	}
+
+	rsp := struct {
+		BlockList []*BlockListResponseJsonItem `json:"block_list"`
+	}{
+		BlockList: blockList,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/pollenjp/gameserver-go/api/entity"
 	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/textpolicy"
 )
 
 //go:generate go run github.com/matryer/moq -out create_user_moq_test.go . CreateUserService
@@ -21,17 +23,29 @@ type CreateUserService interface {
 }
 
 type CreateUser struct {
-	Service   CreateUserService
-	Validator *validator.Validate
+	Service CreateUserService
+	// nil の場合はアクセストークン (JWT) を発行しない
+	Issuer AccessTokenIssuer
+	// nil の場合は署名用シークレットを返さない
+	Signer SigningSecretIssuer
+	// nil の場合はユーザー名を正規化・検証しない
+	NamePolicy *textpolicy.Policy
+	Validator  *validator.Validate
 }
 
 type CreateUserRequestJson struct {
-	Name         string                    `json:"user_name" validate:"required"`
+	Name         string                    `json:"user_name" validate:"required,max=255"`
 	LeaderCardId entity.LeaderCardIdIDType `json:"leader_card_id" validate:"required"`
 }
 
 type CreateUserResponseJson struct {
 	Token entity.UserTokenType `json:"user_token"`
+	// JWT が有効な場合のみ
+	AccessToken          entity.UserTokenType `json:"access_token,omitempty"`
+	AccessTokenExpiresAt *time.Time           `json:"access_token_expires_at,omitempty"`
+	// リクエストの署名が有効な場合のみ (それぞれのトークンでの署名に使う)
+	SigningSecret            string `json:"signing_secret,omitempty"`
+	AccessTokenSigningSecret string `json:"access_token_signing_secret,omitempty"`
 }
 
 func (ru *CreateUser) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -55,6 +69,14 @@ func (ru *CreateUser) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if ru.NamePolicy != nil {
+		name, ok := handler.CheckTextField(ctx, w, ru.NamePolicy, "user_name", body.Name)
+		if !ok {
+			return
+		}
+		body.Name = name
+	}
+
 	u, err := ru.Service.CreateUser(ctx, body.Name, body.LeaderCardId)
 	if err != nil {
 		handler.RespondJson(ctx, w, &handler.ErrResponse{
@@ -66,5 +88,22 @@ func (ru *CreateUser) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	rsp := CreateUserResponseJson{
 		Token: u.Token,
 	}
+	if ru.Signer != nil {
+		rsp.SigningSecret = ru.Signer.SigningSecret(u.Token)
+	}
+	if ru.Issuer != nil {
+		token, expiresAt, err := ru.Issuer.IssueAccessToken(u.Id)
+		if err != nil {
+			handler.RespondJson(ctx, w, &handler.ErrResponse{
+				Message: err.Error(),
+			}, http.StatusInternalServerError)
+			return
+		}
+		rsp.AccessToken = token
+		rsp.AccessTokenExpiresAt = &expiresAt
+		if ru.Signer != nil {
+			rsp.AccessTokenSigningSecret = ru.Signer.SigningSecret(token)
+		}
+	}
 	handler.RespondJson(ctx, w, rsp, http.StatusOK)
 }
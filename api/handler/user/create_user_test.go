@@ -11,6 +11,7 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/pollenjp/gameserver-go/api/entity"
 	"github.com/pollenjp/gameserver-go/api/testutil"
+	"github.com/pollenjp/gameserver-go/api/textpolicy"
 )
 
 func TestRegisterUser(t *testing.T) {
@@ -39,6 +40,13 @@ func TestRegisterUser(t *testing.T) {
 				rspFile: "testdata/create_user/bad_empty_username/res.json.golden",
 			},
 		},
+		"bad_ng_word_username": {
+			reqFile: "testdata/create_user/bad_ng_word_username/req.json.golden",
+			want: want{
+				status:  400, // http.StatusBadRequest
+				rspFile: "testdata/create_user/bad_ng_word_username/res.json.golden",
+			},
+		},
 	}
 
 	for n, tt := range tests {
@@ -69,8 +77,9 @@ func TestRegisterUser(t *testing.T) {
 				return nil, errors.New("error from mock")
 			}
 			sut := CreateUser{
-				Service:   moq,
-				Validator: validator.New(),
+				Service:    moq,
+				NamePolicy: textpolicy.New(1, 16, 255, false, []string{"badword"}),
+				Validator:  validator.New(),
 			}
 			sut.ServeHTTP(w, r)
 
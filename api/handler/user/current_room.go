@@ -0,0 +1,76 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out current_room_detail_moq_test.go . CurrentRoomDetailService
+type CurrentRoomDetailService interface {
+	GetCurrentRoom(
+		ctx context.Context,
+		userId entity.UserId,
+	) (*service.CurrentRoomResult, error)
+}
+
+type CurrentRoom struct {
+	Service   CurrentRoomDetailService
+	Validator *validator.Validate
+}
+
+// 参加中のルームが無い場合は room_id が 0 になる
+type CurrentRoomResponseJson struct {
+	RoomId           entity.RoomId         `json:"room_id"`
+	LiveId           entity.LiveId         `json:"live_id"`
+	Status           entity.RoomStatus     `json:"status"`
+	Round            int                   `json:"round"`
+	IsHost           bool                  `json:"is_host"`
+	IsSpectator      bool                  `json:"is_spectator"`
+	UserStatus       entity.RoomUserStatus `json:"user_status"`
+	SelectDifficulty entity.LiveDifficulty `json:"select_difficulty"`
+	StartAt          *time.Time            `json:"start_at"`
+	ResultDeadline   *time.Time            `json:"result_deadline"`
+}
+
+func (ru *CurrentRoom) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	result, err := ru.Service.GetCurrentRoom(ctx, userId)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	rsp := CurrentRoomResponseJson{}
+	if result.Room != nil {
+		rsp = CurrentRoomResponseJson{
+			RoomId:           result.Room.Id,
+			LiveId:           result.Room.LiveId,
+			Status:           result.Room.Status,
+			Round:            result.Room.Round,
+			IsHost:           result.Room.HostUserId == userId,
+			IsSpectator:      result.RoomUser.IsSpectator(),
+			UserStatus:       result.RoomUser.Status,
+			SelectDifficulty: result.RoomUser.LiveDifficulty,
+			StartAt:          result.Room.StartAt,
+			ResultDeadline:   result.ResultDeadline,
+		}
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,53 @@
+package user
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out friend_code_moq_test.go . FriendCodeService
+type FriendCodeService interface {
+	GetFriendCode(
+		ctx context.Context,
+		userId entity.UserId,
+	) (entity.FriendCodeType, error)
+}
+
+type FriendCode struct {
+	Service   FriendCodeService
+	Validator *validator.Validate
+}
+
+type FriendCodeResponseJson struct {
+	FriendCode entity.FriendCodeType `json:"friend_code"`
+}
+
+func (ru *FriendCode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, isOk := service.GetUserId(ctx)
+	if !isOk {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from token",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	friendCode, err := ru.Service.GetFriendCode(ctx, userId)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	rsp := FriendCodeResponseJson{
+		FriendCode: friendCode,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,77 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+//go:generate go run github.com/matryer/moq -out issue_transfer_moq_test.go . IssueTransferService
+type IssueTransferService interface {
+	IssueUserTransfer(
+		ctx context.Context,
+		userId entity.UserId,
+		password string,
+	) (*entity.UserTransfer, error)
+}
+
+type IssueTransfer struct {
+	Service   IssueTransferService
+	Validator *validator.Validate
+}
+
+type IssueTransferResponseJson struct {
+	TransferId entity.TransferIdType `json:"transfer_id"`
+	ExpiresAt  time.Time             `json:"expires_at"`
+}
+
+func (ru *IssueTransfer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		// bcrypt は 72 byte までしか扱えない
+		Password string `json:"password" validate:"required,min=8,max=64"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	transfer, err := ru.Service.IssueUserTransfer(ctx, userId, body.Password)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	rsp := IssueTransferResponseJson{
+		TransferId: transfer.TransferId,
+		ExpiresAt:  transfer.ExpiresAt,
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,73 @@
+package user
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out access_token_issuer_moq_test.go . AccessTokenIssuer
+type AccessTokenIssuer interface {
+	IssueAccessToken(userId entity.UserId) (entity.UserTokenType, time.Time, error)
+}
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out signing_secret_issuer_moq_test.go . SigningSecretIssuer
+type SigningSecretIssuer interface {
+	SigningSecret(token entity.UserTokenType) string
+}
+
+type Login struct {
+	Issuer AccessTokenIssuer
+	// nil の場合は署名用シークレットを返さない
+	Signer    SigningSecretIssuer
+	Validator *validator.Validate
+}
+
+type AccessTokenResponseJson struct {
+	AccessToken entity.UserTokenType `json:"access_token"`
+	ExpiresAt   time.Time            `json:"expires_at"`
+	// リクエストの署名が有効な場合のみ (アクセストークンでの署名に使う)
+	SigningSecret string `json:"signing_secret,omitempty"`
+}
+
+// opaque なトークン (`/user/create` の user_token) で認証し、短命なアクセストークン (JWT) を発行する
+func (ru *Login) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+	// アクセストークンでアクセストークンを延長できないようにする
+	if _, ok := service.GetSessionId(ctx); !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "login requires user_token",
+		}, http.StatusUnauthorized)
+		return
+	}
+
+	token, expiresAt, err := ru.Issuer.IssueAccessToken(userId)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	rsp := AccessTokenResponseJson{
+		AccessToken: token,
+		ExpiresAt:   expiresAt,
+	}
+	if ru.Signer != nil {
+		rsp.SigningSecret = ru.Signer.SigningSecret(token)
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,76 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+)
+
+//go:generate go run github.com/matryer/moq -out redeem_transfer_moq_test.go . RedeemTransferService
+type RedeemTransferService interface {
+	RedeemUserTransfer(
+		ctx context.Context,
+		transferId entity.TransferIdType,
+		password string,
+	) (*entity.UserSession, error)
+}
+
+type RedeemTransfer struct {
+	Service RedeemTransferService
+	// nil の場合は署名用シークレットを返さない
+	Signer    SigningSecretIssuer
+	Validator *validator.Validate
+}
+
+// 新しい端末から認証なしで呼び出す
+func (ru *RedeemTransfer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var body struct {
+		TransferId entity.TransferIdType `json:"transfer_id" validate:"required"`
+		Password   string                `json:"password" validate:"required,max=64"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: fmt.Sprintf("decode json: %s", err.Error()),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	if err := ru.Validator.Struct(body); err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	session, err := ru.Service.RedeemUserTransfer(ctx, body.TransferId, body.Password)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.As(err, new(*entity.ErrUnauthorized)):
+			status = http.StatusUnauthorized
+		case errors.As(err, new(*entity.ErrTooManyRequests)):
+			status = http.StatusTooManyRequests
+		}
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, status)
+		return
+	}
+
+	rsp := UserTokenResponseJson{
+		Token:     session.Token,
+		ExpiresAt: session.ExpiresAt,
+	}
+	if ru.Signer != nil {
+		rsp.SigningSecret = ru.Signer.SigningSecret(session.Token)
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
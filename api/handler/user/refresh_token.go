@@ -0,0 +1,73 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out refresh_token_moq_test.go . RefreshTokenService
+type RefreshTokenService interface {
+	RefreshUserToken(
+		ctx context.Context,
+		userId entity.UserId,
+		sessionId entity.UserSessionId,
+	) (*entity.UserSession, error)
+}
+
+type RefreshToken struct {
+	Service RefreshTokenService
+	// nil の場合は署名用シークレットを返さない
+	Signer    SigningSecretIssuer
+	Validator *validator.Validate
+}
+
+type UserTokenResponseJson struct {
+	Token     entity.UserTokenType `json:"user_token"`
+	ExpiresAt time.Time            `json:"expires_at"`
+	// リクエストの署名が有効な場合のみ
+	SigningSecret string `json:"signing_secret,omitempty"`
+}
+
+// リクエストに使ったトークンは無効になる
+func (ru *RefreshToken) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+	// アクセストークン (JWT) にはセッションが無い
+	sessionId, ok := service.GetSessionId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "token refresh requires user_token",
+		}, http.StatusUnauthorized)
+		return
+	}
+
+	session, err := ru.Service.RefreshUserToken(ctx, userId, sessionId)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	rsp := UserTokenResponseJson{
+		Token:     session.Token,
+		ExpiresAt: session.ExpiresAt,
+	}
+	if ru.Signer != nil {
+		rsp.SigningSecret = ru.Signer.SigningSecret(session.Token)
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
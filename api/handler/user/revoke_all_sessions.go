@@ -0,0 +1,56 @@
+package user
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// TODO: convert to //go:generate when writing tests
+// go:generate go run github.com/matryer/moq -out revoke_all_sessions_moq_test.go . RevokeAllSessionsService
+type RevokeAllSessionsService interface {
+	RevokeAllUserSessions(
+		ctx context.Context,
+		userId entity.UserId,
+	) (*entity.UserSession, error)
+}
+
+type RevokeAllSessions struct {
+	Service RevokeAllSessionsService
+	// nil の場合は署名用シークレットを返さない
+	Signer    SigningSecretIssuer
+	Validator *validator.Validate
+}
+
+// 他の端末を含む全てのトークンを無効化し、新しいトークンを返す
+func (ru *RevokeAllSessions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userId, ok := service.GetUserId(ctx)
+	if !ok {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: "failed to get user id from context",
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	session, err := ru.Service.RevokeAllUserSessions(ctx, userId)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	rsp := UserTokenResponseJson{
+		Token:     session.Token,
+		ExpiresAt: session.ExpiresAt,
+	}
+	if ru.Signer != nil {
+		rsp.SigningSecret = ru.Signer.SigningSecret(session.Token)
+	}
+	handler.RespondJson(ctx, w, rsp, http.StatusOK)
+}
@@ -0,0 +1,177 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestIssueTransfer(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+
+	tests := map[string]struct {
+		reqFile string
+		want    want
+	}{
+		"ok": {
+			reqFile: "testdata/issue_transfer/ok/req.json.golden",
+			want: want{
+				status:  200, // http.StatusOK
+				rspFile: "testdata/issue_transfer/ok/res.json.golden",
+			},
+		},
+		"bad_short_password": {
+			reqFile: "testdata/issue_transfer/bad_short_password/req.json.golden",
+			want: want{
+				status:  400, // http.StatusBadRequest
+				rspFile: "testdata/issue_transfer/bad_short_password/res.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/user/transfer/issue",
+				bytes.NewReader(testutil.LoadFile(t, tt.reqFile)),
+			)
+			r = r.WithContext(service.SetUserId(r.Context(), 1))
+
+			moq := &IssueTransferServiceMock{}
+			moq.IssueUserTransferFunc = func(
+				_ context.Context,
+				userId entity.UserId,
+				_ string,
+			) (*entity.UserTransfer, error) {
+				return &entity.UserTransfer{
+					UserId:     userId,
+					TransferId: "ABCDEFGHJKLM",
+					ExpiresAt:  clock.FixedClocker{}.Now().Add(config.TransferLifetime),
+				}, nil
+			}
+			sut := IssueTransfer{
+				Service:   moq,
+				Validator: validator.New(),
+			}
+			sut.ServeHTTP(w, r)
+
+			rsp := w.Result()
+			testutil.AssertResponse(
+				t,
+				rsp,
+				tt.want.status,
+				testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
+
+func TestRedeemTransfer(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		status  int
+		rspFile string
+	}
+
+	tests := map[string]struct {
+		reqFile string
+		// サービスが返すエラー
+		err  error
+		want want
+	}{
+		"ok": {
+			reqFile: "testdata/redeem_transfer/ok/req.json.golden",
+			want: want{
+				status:  200, // http.StatusOK
+				rspFile: "testdata/redeem_transfer/ok/res.json.golden",
+			},
+		},
+		"unauthorized": {
+			reqFile: "testdata/redeem_transfer/unauthorized/req.json.golden",
+			err:     &entity.ErrUnauthorized{},
+			want: want{
+				status:  401, // http.StatusUnauthorized
+				rspFile: "testdata/redeem_transfer/unauthorized/res.json.golden",
+			},
+		},
+		"locked": {
+			reqFile: "testdata/redeem_transfer/locked/req.json.golden",
+			err:     &entity.ErrTooManyRequests{},
+			want: want{
+				status:  429, // http.StatusTooManyRequests
+				rspFile: "testdata/redeem_transfer/locked/res.json.golden",
+			},
+		},
+		"bad_empty_transfer_id": {
+			reqFile: "testdata/redeem_transfer/bad_empty_transfer_id/req.json.golden",
+			want: want{
+				status:  400, // http.StatusBadRequest
+				rspFile: "testdata/redeem_transfer/bad_empty_transfer_id/res.json.golden",
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(
+				http.MethodPost,
+				"/user/transfer/redeem",
+				bytes.NewReader(testutil.LoadFile(t, tt.reqFile)),
+			)
+
+			moq := &RedeemTransferServiceMock{}
+			moq.RedeemUserTransferFunc = func(
+				_ context.Context,
+				_ entity.TransferIdType,
+				_ string,
+			) (*entity.UserSession, error) {
+				if tt.err != nil {
+					return nil, fmt.Errorf("RedeemUserTransfer: %w", tt.err)
+				}
+				return &entity.UserSession{
+					UserId:    1,
+					Token:     "7e13ae4f-bd7f-4c2a-ad90-8c2c7bf6f425",
+					ExpiresAt: clock.FixedClocker{}.Now().Add(config.SessionLifetime),
+				}, nil
+			}
+			sut := RedeemTransfer{
+				Service:   moq,
+				Validator: validator.New(),
+			}
+			sut.ServeHTTP(w, r)
+
+			rsp := w.Result()
+			testutil.AssertResponse(
+				t,
+				rsp,
+				tt.want.status,
+				testutil.LoadFile(t, tt.want.rspFile),
+			)
+		})
+	}
+}
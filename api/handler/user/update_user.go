@@ -10,6 +10,7 @@ import (
 	"github.com/pollenjp/gameserver-go/api/entity"
 	"github.com/pollenjp/gameserver-go/api/handler"
 	"github.com/pollenjp/gameserver-go/api/service"
+	"github.com/pollenjp/gameserver-go/api/textpolicy"
 )
 
 // TODO: convert to //go:generate when writing tests
@@ -22,12 +23,14 @@ type UpdateUserService interface {
 }
 
 type UpdateUser struct {
-	Service   UpdateUserService
-	Validator *validator.Validate
+	Service UpdateUserService
+	// nil の場合はユーザー名を正規化・検証しない
+	NamePolicy *textpolicy.Policy
+	Validator  *validator.Validate
 }
 
 type UpdateUserRequestJson struct {
-	Name         string                    `json:"user_name" validate:"required"`
+	Name         string                    `json:"user_name" validate:"required,max=255"`
 	LeaderCardId entity.LeaderCardIdIDType `json:"leader_card_id" validate:"required"`
 }
 
@@ -56,6 +59,14 @@ func (ru *UpdateUser) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if ru.NamePolicy != nil {
+		name, ok := handler.CheckTextField(ctx, w, ru.NamePolicy, "user_name", body.Name)
+		if !ok {
+			return
+		}
+		body.Name = name
+	}
+
 	err := ru.Service.UpdateUser(ctx, &entity.User{
 		Id:           userId,
 		Name:         body.Name,
@@ -18,15 +18,26 @@ type GetUserService interface {
 	) (*entity.User, error)
 }
 
+//go:generate go run github.com/matryer/moq -out current_room_moq_test.go . CurrentRoomService
+type CurrentRoomService interface {
+	GetCurrentRoomId(
+		ctx context.Context,
+		userId entity.UserId,
+	) (entity.RoomId, error)
+}
+
 type UserMe struct {
-	Service   GetUserService
-	Validator *validator.Validate
+	Service     GetUserService
+	RoomService CurrentRoomService
+	Validator   *validator.Validate
 }
 
 type UserMeResponseJson struct {
 	Id           entity.UserId             `json:"id"`
 	Name         string                    `json:"name"`
 	LeaderCardId entity.LeaderCardIdIDType `json:"leader_card_id"`
+	// 参加中のルーム (参加していない場合は 0)
+	CurrentRoomId entity.RoomId `json:"current_room_id"`
 }
 
 func (ru *UserMe) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -47,10 +58,19 @@ func (ru *UserMe) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	currentRoomId, err := ru.RoomService.GetCurrentRoomId(ctx, userId)
+	if err != nil {
+		handler.RespondJson(ctx, w, &handler.ErrResponse{
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
 	rsp := UserMeResponseJson{
-		Id:           u.Id,
-		Name:         u.Name,
-		LeaderCardId: u.LeaderCardId,
+		Id:            u.Id,
+		Name:          u.Name,
+		LeaderCardId:  u.LeaderCardId,
+		CurrentRoomId: currentRoomId,
 	}
 	handler.RespondJson(ctx, w, rsp, http.StatusOK)
 }
@@ -71,13 +71,22 @@ func TestUserMe(t *testing.T) {
 				return nil, errors.New("error from mock")
 			}
 
+			roomMoq := &CurrentRoomServiceMock{}
+			roomMoq.GetCurrentRoomIdFunc = func(
+				_ context.Context,
+				_ entity.UserId,
+			) (entity.RoomId, error) {
+				return entity.RoomId(2), nil
+			}
+
 			// 認証情報の追加
 			ctx := service.SetUserId(r.Context(), dummyUser.Id)
 			r = r.Clone(ctx)
 
 			sut := UserMe{
-				Service:   moq,
-				Validator: validator.New(),
+				Service:     moq,
+				RoomService: roomMoq,
+				Validator:   validator.New(),
 			}
 			sut.ServeHTTP(w, r)
 
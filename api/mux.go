@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -9,20 +10,101 @@ import (
 	"github.com/pollenjp/gameserver-go/api/auth"
 	"github.com/pollenjp/gameserver-go/api/clock"
 	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
 	"github.com/pollenjp/gameserver-go/api/handler"
+	"github.com/pollenjp/gameserver-go/api/handler/admin"
+	"github.com/pollenjp/gameserver-go/api/handler/friend"
 	"github.com/pollenjp/gameserver-go/api/handler/room"
+	"github.com/pollenjp/gameserver-go/api/handler/system"
 	"github.com/pollenjp/gameserver-go/api/handler/user"
 	"github.com/pollenjp/gameserver-go/api/repository"
 	"github.com/pollenjp/gameserver-go/api/service"
+	"github.com/pollenjp/gameserver-go/api/store"
+	"github.com/pollenjp/gameserver-go/api/textpolicy"
 )
 
 // multiplexer
+//
+// 管理 API は ADMIN_ON_PUBLIC_PORT が設定されている場合のみ `/admin` に含める
 func NewMux(ctx context.Context, cfg *config.Config) (
 	http.Handler,
 	func(), // cleanup func
 	error,
+) {
+	mux, _, cleanup, err := NewMuxWithAdmin(ctx, cfg)
+	return mux, cleanup, err
+}
+
+// ADMIN_ON_PUBLIC_PORT が設定されていない場合は管理 API を別の multiplexer として返す (それ以外は nil)
+func NewMuxWithAdmin(ctx context.Context, cfg *config.Config) (
+	http.Handler,
+	http.Handler, // admin
+	func(), // cleanup func
+	error,
 ) {
 	mux := chi.NewRouter()
+	// リクエスト Id は監査ログに記録する
+	mux.Use(handler.RequestIdMiddleware)
+
+	db, cleanup, err := repository.New(ctx, cfg)
+	if err != nil {
+		return nil, nil, cleanup, err
+	}
+	c := clock.RealClocker{}
+	r := &repository.Repository{Clocker: c, TokenPepper: cfg.TokenPepper}
+	au := auth.NewAuthorizer(db, r, c)
+	// JWT_KEYS が設定されている場合のみアクセストークン (JWT) を発行・検証する
+	if len(cfg.JWTKeys) > 0 {
+		jwt, err := auth.NewJWT(cfg.JWTAlgorithm, cfg.JWTKeys, cfg.JWTSigningKid, config.AccessTokenLifetime, c)
+		if err != nil {
+			return nil, nil, cleanup, err
+		}
+		au.JWT = jwt
+	}
+	// nil の *store.SessionCache を interface に入れないように分けて持つ
+	var sessionCache service.SessionCacheInvalidator
+	if cfg.AuthCacheSize > 0 {
+		au.Cache = store.NewSessionCache(c, config.AuthCacheTTL, cfg.AuthCacheSize)
+		sessionCache = au.Cache
+	}
+	// メンテナンスモードは管理 API で切り替え、全てのサーバーで DB を通して共有する
+	maintenance := &service.Maintenance{
+		DB:      db,
+		Repo:    r,
+		Clocker: c,
+	}
+	mux.Use(handler.MaintenanceMiddleware(maintenance, c))
+	// MIN_CLIENT_VERSION が設定されている場合のみ古いクライアントを拒否する
+	if cfg.MinClientVersion != "" {
+		minVersion, err := entity.ParseClientVersion(cfg.MinClientVersion)
+		if err != nil {
+			return nil, nil, cleanup, err
+		}
+		mux.Use(handler.ClientVersionMiddleware(minVersion, cfg.ClientStoreURL, cfg.ClientVersionRequired))
+	}
+	// REQUEST_SIGNING_KEY が設定されている場合のみ署名用シークレットを発行し、REQUEST_SIGNING_PATHS で署名を検証する
+	var signer *auth.RequestSigner
+	if cfg.RequestSigningKey != "" {
+		signer = auth.NewRequestSigner(cfg.RequestSigningKey, config.RequestSignatureMaxSkew, c)
+		mux.Use(handler.RequestSignatureMiddleware(signer, cfg.RequestSigningPaths))
+	}
+	// ユーザー名・チャットの NG ワード (NG_WORDS と NG_WORDS_FILE の両方を使う)
+	ngWords := cfg.NGWords
+	if cfg.NGWordsFile != "" {
+		words, err := textpolicy.LoadWords(cfg.NGWordsFile)
+		if err != nil {
+			return nil, nil, cleanup, err
+		}
+		ngWords = append(ngWords, words...)
+	}
+	namePolicy := textpolicy.New(1, config.UserNameMaxLength, config.UserNameMaxRunes, false, ngWords)
+	// Idempotency-Key ヘッダーによる再送の重複排除 (更新系の POST のみ)
+	idem := handler.IdempotencyMiddleware(&service.Idempotency{
+		DB:      db,
+		Repo:    r,
+		Clocker: c,
+	})
+
 	mux.HandleFunc(
 		"/health",
 		func(w http.ResponseWriter, _ *http.Request) {
@@ -31,13 +113,12 @@ func NewMux(ctx context.Context, cfg *config.Config) (
 		},
 	)
 
-	db, cleanup, err := repository.New(ctx, cfg)
-	if err != nil {
-		return nil, cleanup, err
+	{
+		st := &system.ServerTime{
+			Clocker: c,
+		}
+		mux.Get("/time", st.ServeHTTP)
 	}
-	c := clock.RealClocker{}
-	r := &repository.Repository{Clocker: c}
-	au := auth.NewAuthorizer(db, r)
 
 	{
 		cu := &user.CreateUser{
@@ -45,51 +126,196 @@ func NewMux(ctx context.Context, cfg *config.Config) (
 				DB:   db,
 				Repo: r,
 			},
-			Validator: validator.New(),
+			NamePolicy: namePolicy,
+			Validator:  validator.New(),
+		}
+		// interface に nil の *auth.JWT を入れないようにする
+		if au.JWT != nil {
+			cu.Issuer = au.JWT
+		}
+		if signer != nil {
+			cu.Signer = signer
 		}
 		me := &user.UserMe{
 			Service: &service.GetUser{
 				DB:   db,
 				Repo: r,
 			},
+			RoomService: &service.GetCurrentRoom{
+				DB:   db,
+				Repo: r,
+			},
+			Validator: validator.New(),
+		}
+		fc := &user.FriendCode{
+			Service: &service.GetFriendCode{
+				DB:   db,
+				Repo: r,
+			},
 			Validator: validator.New(),
 		}
 		uu := &user.UpdateUser{
 			Service: &service.UpdateUser{
+				DB:    db,
+				Repo:  r,
+				Cache: sessionCache,
+			},
+			NamePolicy: namePolicy,
+			Validator:  validator.New(),
+		}
+		us := &service.UserSession{
+			DB:    db,
+			Repo:  r,
+			Cache: sessionCache,
+		}
+		rt := &user.RefreshToken{
+			Service:   us,
+			Validator: validator.New(),
+		}
+		ras := &user.RevokeAllSessions{
+			Service:   us,
+			Validator: validator.New(),
+		}
+		ut := &service.UserTransfer{
+			DB:      db,
+			Repo:    r,
+			Clocker: c,
+			Cache:   sessionCache,
+		}
+		it := &user.IssueTransfer{
+			Service:   ut,
+			Validator: validator.New(),
+		}
+		rdt := &user.RedeemTransfer{
+			Service:   ut,
+			Validator: validator.New(),
+		}
+		if signer != nil {
+			rt.Signer = signer
+			ras.Signer = signer
+			rdt.Signer = signer
+		}
+		cur := &user.CurrentRoom{
+			Service: &service.GetCurrentRoom{
+				DB:   db,
+				Repo: r,
+			},
+			Validator: validator.New(),
+		}
+		bu := &user.BlockUser{
+			Service: &service.BlockUser{
 				DB:   db,
 				Repo: r,
 			},
 			Validator: validator.New(),
 		}
+		ub := &user.UnblockUser{
+			Service: &service.UnblockUser{
+				DB:   db,
+				Repo: r,
+			},
+			Validator: validator.New(),
+		}
+		bl := &user.BlockList{
+			Service: &service.GetBlockList{
+				DB:   db,
+				Repo: r,
+			},
+			Validator: validator.New(),
+		}
+		// ルートグループ毎にバケットを分ける
+		limit := handler.RateLimitMiddleware(store.NewRateLimiter(c, cfg.UserRateLimitInterval, cfg.UserRateLimitBurst), cfg.TrustedProxyHeader)
+		createLimit := handler.RateLimitMiddleware(store.NewRateLimiter(c, cfg.CreateRateLimitInterval, cfg.CreateRateLimitBurst), cfg.TrustedProxyHeader)
 		mux.Route("/user", func(r chi.Router) {
-			r.Post("/create", cu.ServeHTTP)
-			r.Get("/me", handler.AuthMiddleware(au)(me).ServeHTTP)
-			r.Post("/update", handler.AuthMiddleware(au)(uu).ServeHTTP)
+			r.Post("/create", createLimit(idem(cu)).ServeHTTP)
+			r.Get("/me", handler.AuthMiddleware(au)(limit(me)).ServeHTTP)
+			r.Post("/update", handler.AuthMiddleware(au)(limit(idem(uu))).ServeHTTP)
+			if au.JWT != nil {
+				lg := &user.Login{
+					Issuer:    au.JWT,
+					Validator: validator.New(),
+				}
+				if signer != nil {
+					lg.Signer = signer
+				}
+				r.Post("/login", handler.AuthMiddleware(au)(limit(lg)).ServeHTTP)
+			}
+			r.Post("/token/refresh", handler.AuthMiddleware(au)(limit(idem(rt))).ServeHTTP)
+			r.Post("/token/revoke_all", handler.AuthMiddleware(au)(limit(idem(ras))).ServeHTTP)
+			r.Post("/transfer/issue", handler.AuthMiddleware(au)(limit(idem(it))).ServeHTTP)
+			r.Post("/transfer/redeem", limit(idem(rdt)).ServeHTTP)
+			r.Get("/current_room", handler.AuthMiddleware(au)(limit(cur)).ServeHTTP)
+			r.Get("/friend_code", handler.AuthMiddleware(au)(limit(fc)).ServeHTTP)
+			r.Post("/block", handler.AuthMiddleware(au)(limit(idem(bu))).ServeHTTP)
+			r.Post("/unblock", handler.AuthMiddleware(au)(limit(idem(ub))).ServeHTTP)
+			r.Get("/block_list", handler.AuthMiddleware(au)(limit(bl)).ServeHTTP)
 		})
 	}
 
 	{
-		cr := &room.CreateRoom{
-			Service: &service.CreateRoom{
+		rf := &friend.RequestFriend{
+			Service: &service.RequestFriend{
 				DB:   db,
 				Repo: r,
 			},
 			Validator: validator.New(),
 		}
-		rl := &room.GetRoomList{
-			Service: &service.GetRoomList{
+		af := &friend.AcceptFriend{
+			Service: &service.AcceptFriend{
 				DB:   db,
 				Repo: r,
 			},
 			Validator: validator.New(),
 		}
-		jr := &room.JoinRoom{
-			Service: &service.JoinRoom{
+		rmf := &friend.RemoveFriend{
+			Service: &service.RemoveFriend{
+				DB:   db,
+				Repo: r,
+			},
+			Validator: validator.New(),
+		}
+		fl := &friend.FriendList{
+			Service: &service.GetFriendList{
+				DB:   db,
+				Repo: r,
+			},
+			Validator: validator.New(),
+		}
+		limit := handler.RateLimitMiddleware(store.NewRateLimiter(c, cfg.FriendRateLimitInterval, cfg.FriendRateLimitBurst), cfg.TrustedProxyHeader)
+		mux.Route("/friend", func(r chi.Router) {
+			r.Post("/request", handler.AuthMiddleware(au)(limit(idem(rf))).ServeHTTP)
+			r.Post("/accept", handler.AuthMiddleware(au)(limit(idem(af))).ServeHTTP)
+			r.Post("/remove", handler.AuthMiddleware(au)(limit(idem(rmf))).ServeHTTP)
+			r.Get("/list", handler.AuthMiddleware(au)(limit(fl)).ServeHTTP)
+		})
+	}
+
+	{
+		cr := &room.CreateRoom{
+			Service: &service.CreateRoom{
+				DB:                  db,
+				Repo:                r,
+				AutoLeaveActiveRoom: cfg.AutoLeaveActiveRoom,
+			},
+			Validator: validator.New(),
+		}
+		rl := &room.GetRoomList{
+			Service: &service.GetRoomList{
 				DB:   db,
 				Repo: r,
 			},
 			Validator: validator.New(),
 		}
+		// 招待の承諾でも同じ処理で参加させる
+		js := &service.JoinRoom{
+			DB:                  db,
+			Repo:                r,
+			AutoLeaveActiveRoom: cfg.AutoLeaveActiveRoom,
+		}
+		jr := &room.JoinRoom{
+			Service:   js,
+			Validator: validator.New(),
+		}
 		wr := &room.WaitRoom{
 			Service: &service.WaitRoom{
 				DB:   db,
@@ -99,6 +325,14 @@ func NewMux(ctx context.Context, cfg *config.Config) (
 		}
 		sr := &room.StartRoom{
 			Service: &service.StartRoom{
+				DB:      db,
+				Repo:    r,
+				Clocker: c,
+			},
+			Validator: validator.New(),
+		}
+		cl := &room.ChangeRoomLive{
+			Service: &service.ChangeRoomLive{
 				DB:   db,
 				Repo: r,
 			},
@@ -106,18 +340,114 @@ func NewMux(ctx context.Context, cfg *config.Config) (
 		}
 		er := &room.EndRoom{
 			Service: &service.EndRoom{
-				DB:   db,
-				Repo: r,
+				DB:      db,
+				Repo:    r,
+				Clocker: c,
 			},
 			Validator: validator.New(),
 		}
 		rr := &room.RoomResult{
 			Service: &service.GetRoomResult{
+				DB:      db,
+				Repo:    r,
+				Clocker: c,
+			},
+			Validator: validator.New(),
+		}
+		nr := &room.NextRound{
+			Service: &service.NextRound{
+				DB:      db,
+				Repo:    r,
+				Clocker: c,
+			},
+			Validator: validator.New(),
+		}
+		rs := &room.RoomStandings{
+			Service: &service.GetRoomStandings{
+				DB:   db,
+				Repo: r,
+			},
+			Validator: validator.New(),
+		}
+		rm := &room.Rematch{
+			Service: &service.Rematch{
+				DB:                  db,
+				Repo:                r,
+				Clocker:             c,
+				AutoLeaveActiveRoom: cfg.AutoLeaveActiveRoom,
+			},
+			Validator: validator.New(),
+		}
+		ro := &room.RematchOptIn{
+			Service: &service.RematchOptIn{
+				DB:   db,
+				Repo: r,
+			},
+			Validator: validator.New(),
+		}
+		ps := store.NewProgressStore(c)
+		rp := &room.ReportProgress{
+			Service: &service.ReportProgress{
+				DB:    db,
+				Repo:  r,
+				Store: ps,
+			},
+			Validator: validator.New(),
+		}
+		lp := &room.LiveProgress{
+			Service: &service.GetLiveProgress{
+				DB:    db,
+				Repo:  r,
+				Store: ps,
+			},
+			Validator: validator.New(),
+		}
+		pc := &room.PostRoomChat{
+			Service: &service.PostRoomChat{
+				DB:      db,
+				Repo:    r,
+				Clocker: c,
+			},
+			MessagePolicy: textpolicy.New(1, config.ChatMessageMaxLength, config.ChatMessageMaxRunes, true, ngWords),
+			Validator:     validator.New(),
+		}
+		gc := &room.RoomChats{
+			Service: &service.GetRoomChats{
 				DB:   db,
 				Repo: r,
 			},
 			Validator: validator.New(),
 		}
+		ir := &room.InviteRoom{
+			Service: &service.InviteRoom{
+				DB:      db,
+				Repo:    r,
+				Clocker: c,
+			},
+			Validator: validator.New(),
+		}
+		gi := &room.RoomInvitations{
+			Service: &service.GetRoomInvitations{
+				DB:      db,
+				Repo:    r,
+				Clocker: c,
+			},
+			Validator: validator.New(),
+		}
+		ri := &service.RespondRoomInvitation{
+			DB:      db,
+			Repo:    r,
+			Joiner:  js,
+			Clocker: c,
+		}
+		ai := &room.AcceptRoomInvitation{
+			Service:   ri,
+			Validator: validator.New(),
+		}
+		di := &room.DeclineRoomInvitation{
+			Service:   ri,
+			Validator: validator.New(),
+		}
 		lr := &room.LeaveRoom{
 			Service: &service.LeaveRoom{
 				DB:   db,
@@ -125,17 +455,139 @@ func NewMux(ctx context.Context, cfg *config.Config) (
 			},
 			Validator: validator.New(),
 		}
+		limit := handler.RateLimitMiddleware(store.NewRateLimiter(c, cfg.RoomRateLimitInterval, cfg.RoomRateLimitBurst), cfg.TrustedProxyHeader)
+		createLimit := handler.RateLimitMiddleware(store.NewRateLimiter(c, cfg.CreateRateLimitInterval, cfg.CreateRateLimitBurst), cfg.TrustedProxyHeader)
 		mux.Route("/room", func(r chi.Router) {
-			r.Post("/create", handler.AuthMiddleware(au)(cr).ServeHTTP)
-			r.Post("/list", rl.ServeHTTP)
-			r.Post("/join", handler.AuthMiddleware(au)(jr).ServeHTTP)
-			r.Post("/wait", handler.AuthMiddleware(au)(wr).ServeHTTP)
-			r.Post("/start", handler.AuthMiddleware(au)(sr).ServeHTTP)
-			r.Post("/end", handler.AuthMiddleware(au)(er).ServeHTTP)
-			r.Post("/result", handler.AuthMiddleware(au)(rr).ServeHTTP)
-			r.Post("/leave", handler.AuthMiddleware(au)(lr).ServeHTTP)
+			r.Post("/create", handler.AuthMiddleware(au)(createLimit(idem(cr))).ServeHTTP)
+			r.Post("/list", handler.OptionalAuthMiddleware(au)(limit(rl)).ServeHTTP)
+			r.Post("/join", handler.AuthMiddleware(au)(limit(idem(jr))).ServeHTTP)
+			r.Post("/wait", handler.AuthMiddleware(au)(limit(wr)).ServeHTTP)
+			r.Post("/start", handler.AuthMiddleware(au)(limit(idem(sr))).ServeHTTP)
+			r.Post("/change_live", handler.AuthMiddleware(au)(limit(idem(cl))).ServeHTTP)
+			r.Post("/end", handler.AuthMiddleware(au)(limit(idem(er))).ServeHTTP)
+			r.Post("/result", handler.AuthMiddleware(au)(limit(rr)).ServeHTTP)
+			r.Post("/next", handler.AuthMiddleware(au)(limit(idem(nr))).ServeHTTP)
+			r.Post("/standings", handler.AuthMiddleware(au)(limit(rs)).ServeHTTP)
+			r.Post("/rematch", handler.AuthMiddleware(au)(limit(idem(rm))).ServeHTTP)
+			r.Post("/rematch_opt_in", handler.AuthMiddleware(au)(limit(idem(ro))).ServeHTTP)
+			r.Post("/report_progress", handler.AuthMiddleware(au)(limit(rp)).ServeHTTP)
+			r.Post("/progress", handler.AuthMiddleware(au)(limit(lp)).ServeHTTP)
+			r.Post("/chat", handler.AuthMiddleware(au)(limit(idem(pc))).ServeHTTP)
+			r.Get("/chat", handler.AuthMiddleware(au)(limit(gc)).ServeHTTP)
+			r.Post("/invite", handler.AuthMiddleware(au)(limit(idem(ir))).ServeHTTP)
+			r.Get("/invitations", handler.AuthMiddleware(au)(limit(gi)).ServeHTTP)
+			r.Post("/invitation/accept", handler.AuthMiddleware(au)(limit(idem(ai))).ServeHTTP)
+			r.Post("/invitation/decline", handler.AuthMiddleware(au)(limit(idem(di))).ServeHTTP)
+			r.Post("/leave", handler.AuthMiddleware(au)(limit(idem(lr))).ServeHTTP)
 		})
 	}
 
-	return mux, cleanup, nil
+	// ADMIN_CREDENTIALS が設定されていない場合は管理 API を無効にする
+	if len(cfg.AdminCredentials) == 0 {
+		return mux, nil, cleanup, nil
+	}
+	// 管理 API を誤って外部に公開しないように、公開ポートで提供する場合は明示的に設定させる
+	if cfg.AdminPort == 0 && !cfg.AdminOnPublicPort {
+		return nil, nil, cleanup, errors.New("ADMIN_PORT must be set unless ADMIN_ON_PUBLIC_PORT is true")
+	}
+
+	adminMux := chi.NewRouter()
+	adminMux.Use(handler.RequestIdMiddleware)
+	adminMux.Use(handler.AdminAuthMiddleware(&auth.AdminAuthorizer{
+		Credentials: cfg.AdminCredentials,
+	}))
+	{
+		aus := &service.AdminUser{
+			DB:      db,
+			Repo:    r,
+			Clocker: c,
+			Cache:   sessionCache,
+		}
+		ar := &service.AdminRoom{
+			DB:   db,
+			Repo: r,
+		}
+		gu := &admin.GetUser{
+			Service:   aus,
+			Validator: validator.New(),
+		}
+		su := &admin.SearchUsers{
+			Service:   aus,
+			Validator: validator.New(),
+		}
+		rut := &admin.ResetUserToken{
+			Service:   aus,
+			Validator: validator.New(),
+		}
+		if signer != nil {
+			rut.Signer = signer
+		}
+		bu := &admin.BanUser{
+			Service:   aus,
+			Validator: validator.New(),
+		}
+		ubu := &admin.UnbanUser{
+			Service:   aus,
+			Validator: validator.New(),
+		}
+		rm := &admin.RoomMembers{
+			Service:   ar,
+			Validator: validator.New(),
+		}
+		dr := &admin.DissolveRoom{
+			Service:   ar,
+			Validator: validator.New(),
+		}
+		ds := &admin.DeleteScore{
+			Service:   ar,
+			Validator: validator.New(),
+		}
+		als := &service.AuditLog{
+			DB:   db,
+			Repo: r,
+		}
+		al := &admin.AuditLogs{
+			Service:   als,
+			Validator: validator.New(),
+		}
+		eal := &admin.ExportAuditLogs{
+			Service:   als,
+			Validator: validator.New(),
+		}
+		gm := &admin.GetMaintenance{
+			Service:   maintenance,
+			Validator: validator.New(),
+		}
+		sm := &admin.SetMaintenance{
+			Service:   maintenance,
+			Validator: validator.New(),
+		}
+		// 認証のキャッシュの統計は公開しない
+		acs := &admin.AuthCacheStats{}
+		if au.Cache != nil {
+			acs.Cache = au.Cache
+		}
+		adminMux.Get("/user", gu.ServeHTTP)
+		adminMux.Get("/user/search", su.ServeHTTP)
+		adminMux.Post("/user/reset_token", rut.ServeHTTP)
+		adminMux.Post("/user/ban", bu.ServeHTTP)
+		adminMux.Post("/user/unban", ubu.ServeHTTP)
+		adminMux.Get("/room/members", rm.ServeHTTP)
+		adminMux.Post("/room/dissolve", dr.ServeHTTP)
+		adminMux.Post("/score/delete", ds.ServeHTTP)
+		adminMux.Get("/audit_log", al.ServeHTTP)
+		adminMux.Get("/audit_log/export", eal.ServeHTTP)
+		adminMux.Get("/maintenance", gm.ServeHTTP)
+		adminMux.Post("/maintenance", sm.ServeHTTP)
+		adminMux.Get("/auth_cache", acs.ServeHTTP)
+	}
+
+	if cfg.AdminOnPublicPort {
+		mux.Mount("/admin", adminMux)
+		return mux, nil, cleanup, nil
+	}
+	// 別のポートでも同じパス (`/admin/...`) で提供する
+	adminRoot := chi.NewRouter()
+	adminRoot.Mount("/admin", adminMux)
+	return mux, adminRoot, cleanup, nil
 }
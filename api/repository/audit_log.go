@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+func (r *Repository) CreateAuditLog(
+	ctx context.Context,
+	db service.Execer,
+	log *entity.AuditLog,
+) error {
+	log.CreatedAt = r.Clocker.Now()
+
+	sql := `
+	INSERT INTO
+		audit_log
+		(
+			actor_user_id,
+			actor_admin_name,
+			action,
+			target_user_id,
+			target_room_id,
+			request_id,
+			detail,
+			created_at
+		)
+	VALUES
+		(?, ?, ?, ?, ?, ?, ?, ?)
+	;`
+
+	result, err := db.ExecContext(
+		ctx,
+		sql,
+		log.ActorUserId,
+		log.ActorAdminName,
+		log.Action,
+		log.TargetUserId,
+		log.TargetRoomId,
+		log.RequestId,
+		log.Detail,
+		log.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("CreateAuditLog: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("CreateAuditLog: %w", err)
+	}
+	log.Id = entity.AuditLogId(id)
+	return nil
+}
+
+// filter に一致するログを id の昇順で最大 limit 件取得する
+func (r *Repository) GetAuditLogs(
+	ctx context.Context,
+	db service.Queryer,
+	filter *entity.AuditLogFilter,
+	limit int,
+) ([]*entity.AuditLog, error) {
+	logs := []*entity.AuditLog{}
+
+	sql := `
+	SELECT
+		id,
+		actor_user_id,
+		actor_admin_name,
+		action,
+		target_user_id,
+		target_room_id,
+		request_id,
+		detail,
+		created_at
+	FROM
+		audit_log
+	WHERE
+		id > ?
+		AND
+		(? = 0 OR actor_user_id = ? OR target_user_id = ?)
+		AND
+		(? = 0 OR target_room_id = ?)
+		AND
+		(? = '' OR action = ?)
+	ORDER BY
+		id ASC
+	LIMIT ?
+	;`
+
+	if err := db.SelectContext(
+		ctx,
+		&logs,
+		sql,
+		filter.SinceId,
+		filter.UserId, filter.UserId, filter.UserId,
+		filter.RoomId, filter.RoomId,
+		filter.Action, filter.Action,
+		limit,
+	); err != nil {
+		return nil, fmt.Errorf("GetAuditLogs: %w", err)
+	}
+	return logs, nil
+}
@@ -28,11 +28,12 @@ func (r *Repository) CreateRoom(
 			live_id,
 			host_user_id,
 			status,
+			round,
 			created_at,
 			updated_at
 		)
 	VALUES
-		(?, ?, ?, ?, ?)
+		(?, ?, ?, ?, ?, ?)
 	;`
 
 	result, err := db.ExecContext(
@@ -41,6 +42,7 @@ func (r *Repository) CreateRoom(
 		room.LiveId,
 		room.HostUserId,
 		room.Status,
+		room.Round,
 		room.CreatedAt,
 		room.UpdatedAt,
 	)
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// room_chat table にチャットを追加
+//
+// - 以下の値を設定する
+//   - `entity.RoomChat.Id`
+//   - `entity.RoomChat.CreatedAt`
+func (r *Repository) CreateRoomChat(
+	ctx context.Context,
+	db service.Execer,
+	chat *entity.RoomChat,
+) error {
+	chat.CreatedAt = r.Clocker.Now()
+
+	sql := `
+	INSERT INTO
+		room_chat
+		(
+			room_id,
+			user_id,
+			message,
+			stamp_id,
+			created_at
+		)
+	VALUES
+		(?, ?, ?, ?, ?)
+	;`
+
+	result, err := db.ExecContext(
+		ctx,
+		sql,
+		chat.RoomId,
+		chat.UserId,
+		chat.Message,
+		chat.StampId,
+		chat.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("CreateRoomChat: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("CreateRoomChat: %w", err)
+	}
+
+	chat.Id = entity.RoomChatId(id)
+	return nil
+}
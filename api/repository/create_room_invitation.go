@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// ルームへの招待を登録する
+//
+// 同じルームに既に招待している場合は招待者と有効期限を更新して Pending に戻す
+func (r *Repository) CreateRoomInvitation(
+	ctx context.Context,
+	db service.Execer,
+	roomId entity.RoomId,
+	inviterUserId entity.UserId,
+	inviteeUserId entity.UserId,
+	expiresAt time.Time,
+) error {
+	sql := `
+	INSERT INTO
+		room_invitation
+		(
+			room_id,
+			inviter_user_id,
+			invitee_user_id,
+			status,
+			expires_at,
+			created_at,
+			updated_at
+		)
+	VALUES
+		(?, ?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		inviter_user_id = VALUES(inviter_user_id),
+		status = VALUES(status),
+		expires_at = VALUES(expires_at),
+		updated_at = VALUES(updated_at)
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		roomId,
+		inviterUserId,
+		inviteeUserId,
+		entity.RoomInvitationStatusPending,
+		expiresAt,
+		r.Clocker.Now(),
+		r.Clocker.Now(),
+	); err != nil {
+		return fmt.Errorf("CreateRoomInvitation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// liveIds[i] を (i+1) ラウンド目の楽曲として登録する
+func (r *Repository) CreateRoomSetlist(
+	ctx context.Context,
+	db service.Execer,
+	roomId entity.RoomId,
+	liveIds []entity.LiveId,
+) ([]*entity.RoomSetlistItem, error) {
+	setlist := make([]*entity.RoomSetlistItem, len(liveIds))
+	for i, liveId := range liveIds {
+		setlist[i] = &entity.RoomSetlistItem{
+			RoomId: roomId,
+			Round:  i + 1,
+			LiveId: liveId,
+		}
+	}
+
+	sql := `
+	INSERT INTO
+		room_setlist
+		(
+			room_id,
+			round,
+			live_id
+		)
+	VALUES
+		(:room_id, :round, :live_id)
+	;`
+
+	for _, item := range setlist {
+		if _, err := db.NamedExecContext(ctx, sql, item); err != nil {
+			return nil, fmt.Errorf("CreateRoomSetlist: %w", err)
+		}
+	}
+	return setlist, nil
+}
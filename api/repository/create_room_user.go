@@ -45,3 +45,44 @@ func (r *Repository) CreateRoomUser(
 	}
 	return roomUser, nil
 }
+
+// 観戦者としてルームに参加させる
+func (r *Repository) CreateRoomSpectator(
+	ctx context.Context,
+	db service.Execer,
+	roomId entity.RoomId,
+	userId entity.UserId,
+) (*entity.RoomUser, error) {
+	roomUser := entity.NewRoomUser(
+		roomId,
+		userId,
+		entity.LiveDifficultyNormal,
+	)
+	roomUser.Role = entity.RoomUserRoleSpectator
+
+	sql := `
+	INSERT INTO
+		room_user
+		(
+			room_id,
+			user_id,
+			live_difficulty,
+			role
+		)
+	VALUES
+		(?, ?, ?, ?)
+	;`
+
+	_, err := db.ExecContext(
+		ctx,
+		sql,
+		roomUser.RoomId,
+		roomUser.UserId,
+		roomUser.LiveDifficulty,
+		roomUser.Role,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("CreateRoomSpectator: %w", err)
+	}
+	return roomUser, nil
+}
@@ -18,6 +18,7 @@ func (r *Repository) CreateScore(
 		score
 		(
 			room_id,
+			round,
 			user_id,
 			score,
 			judge_perfect,
@@ -27,13 +28,14 @@ func (r *Repository) CreateScore(
 			judge_miss
 		)
 	VALUES
-		(?, ?, ?, ?, ?, ?, ?, ?)
+		(?, ?, ?, ?, ?, ?, ?, ?, ?)
 	;`
 
 	_, err := db.ExecContext(
 		ctx,
 		sql,
 		score.RoomId,
+		score.Round,
 		score.UserId,
 		score.Score,
 		score.JudgePerfect,
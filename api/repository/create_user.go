@@ -13,60 +13,66 @@ import (
 
 // user table にユーザを追加
 //
-// - DBに登録
+// - DBに登録 (token はハッシュのみ保存する)
 // - 以下の値を設定する
 //   - `entity.User.ID`
+//   - `entity.User.Token` (平文)
+//   - `entity.User.FriendCode`
 //   - `entity.User.Created`
 //   - `entity.User.Modified`
 func (r *Repository) CreateUser(
 	ctx context.Context, db service.Execer, u *entity.User,
 ) error {
-	u.Token = entity.UserTokenType(uuid.NewString())
 	u.CreatedAt = r.Clocker.Now()
 	u.UpdatedAt = r.Clocker.Now()
 
-	// TODO: token が unique でなかった場合に5回程リトライする
-
 	sql := `INSERT INTO
 		user (
 			name,
 			token,
 			leader_card_id,
+			friend_code,
 			created_at,
 			updated_at
 		)
 	VALUES
-		(?, ?, ?, ?, ?)
+		(?, ?, ?, ?, ?, ?)
 	;`
 
-	result, err := db.ExecContext(
-		ctx,
-		sql,
-		u.Name,
-		u.Token,
-		u.LeaderCardId,
-		u.CreatedAt,
-		u.UpdatedAt,
-	)
-	if err != nil {
-		var mysqlErr *mysql.MySQLError
+	// token・フレンドコードが重複した場合は作り直す
+	for trial := 0; trial < 5; trial++ {
+		u.Token = entity.UserTokenType(uuid.NewString())
+		friendCode, err := newFriendCode()
+		if err != nil {
+			return err
+		}
+		u.FriendCode = friendCode
 
-		// primary key 重複エラー
+		result, err := db.ExecContext(
+			ctx,
+			sql,
+			u.Name,
+			r.hashToken(u.Token),
+			u.LeaderCardId,
+			u.FriendCode,
+			u.CreatedAt,
+			u.UpdatedAt,
+		)
+		var mysqlErr *mysql.MySQLError
 		if errors.As(err, &mysqlErr) && mysqlErr.Number == service.ErrCodeMySQLDuplicateEntry {
-			return fmt.Errorf("cannot create same name user: %w", service.ErrAlreadyEntry)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("CreateUser: %w", err)
 		}
 
-		return err
-	}
-
-	id, err := result.LastInsertId()
-	if err != nil {
-		return err
-	}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
 
-	u.Id = entity.UserId(id)
-	if err := u.ValidateNotEmpty(); err != nil {
-		return err
+		u.Id = entity.UserId(id)
+		return u.ValidateNotEmpty()
 	}
-	return nil
+	return fmt.Errorf("CreateUser: %w", service.ErrAlreadyEntry)
 }
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// userId が blockedUserId をブロックする (既にブロックしている場合は何もしない)
+func (r *Repository) CreateUserBlock(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+	blockedUserId entity.UserId,
+) error {
+	sql := `
+	INSERT IGNORE INTO
+		user_block
+		(
+			user_id,
+			blocked_user_id,
+			created_at
+		)
+	VALUES
+		(?, ?, ?)
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		userId,
+		blockedUserId,
+		r.Clocker.Now(),
+	); err != nil {
+		return fmt.Errorf("CreateUserBlock: %w", err)
+	}
+	return nil
+}
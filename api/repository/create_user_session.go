@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// token を認証トークンとするセッションを作成する (有効期間は config.SessionLifetime)
+//
+// DB には token のハッシュのみ保存する
+func (r *Repository) CreateUserSession(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+	token entity.UserTokenType,
+) (*entity.UserSession, error) {
+	now := r.Clocker.Now()
+	session := &entity.UserSession{
+		UserId:    userId,
+		Token:     token,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(config.SessionLifetime),
+	}
+
+	sql := `
+	INSERT INTO
+		user_session
+		(
+			user_id,
+			token,
+			issued_at,
+			expires_at
+		)
+	VALUES
+		(?, ?, ?, ?)
+	;`
+
+	result, err := db.ExecContext(
+		ctx,
+		sql,
+		session.UserId,
+		r.hashToken(session.Token),
+		session.IssuedAt,
+		session.ExpiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("CreateUserSession: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("CreateUserSession: %w", err)
+	}
+	session.Id = entity.UserSessionId(id)
+	return session, nil
+}
+
+// 新しい認証トークンを発行してセッションを作成する
+func (r *Repository) IssueUserSession(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+) (*entity.UserSession, error) {
+	return r.CreateUserSession(ctx, db, userId, entity.UserTokenType(uuid.NewString()))
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// userId と friendUserId の間の関係を両方向とも削除する
+func (r *Repository) DeleteFriend(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+	friendUserId entity.UserId,
+) error {
+	sql := `
+	DELETE FROM
+		friend
+	WHERE
+		(user_id = ? AND friend_user_id = ?)
+		OR
+		(user_id = ? AND friend_user_id = ?)
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		userId,
+		friendUserId,
+		friendUserId,
+		userId,
+	); err != nil {
+		return fmt.Errorf("DeleteFriend: %w", err)
+	}
+	return nil
+}
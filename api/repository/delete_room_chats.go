@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+func (r *Repository) DeleteRoomChats(
+	ctx context.Context,
+	db service.Execer,
+	roomId entity.RoomId,
+) error {
+	sql := `
+	DELETE FROM
+		room_chat
+	WHERE
+		room_id = ?
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		roomId,
+	); err != nil {
+		return fmt.Errorf("DeleteRoomChats: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// 該当するスコアが無い場合は sql.ErrNoRows を返す
+func (r *Repository) DeleteScore(
+	ctx context.Context,
+	db service.Execer,
+	roomId entity.RoomId,
+	round int,
+	userId entity.UserId,
+) error {
+	query := `
+	DELETE FROM
+		score
+	WHERE
+		room_id = ?
+		AND
+		round = ?
+		AND
+		user_id = ?
+	;`
+
+	result, err := db.ExecContext(ctx, query, roomId, round, userId)
+	if err != nil {
+		return fmt.Errorf("DeleteScore: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("DeleteScore: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("DeleteScore: %w", sql.ErrNoRows)
+	}
+	return nil
+}
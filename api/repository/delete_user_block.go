@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+func (r *Repository) DeleteUserBlock(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+	blockedUserId entity.UserId,
+) error {
+	sql := `
+	DELETE FROM
+		user_block
+	WHERE
+		user_id = ?
+		AND
+		blocked_user_id = ?
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		userId,
+		blockedUserId,
+	); err != nil {
+		return fmt.Errorf("DeleteUserBlock: %w", err)
+	}
+	return nil
+}
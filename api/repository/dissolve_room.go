@@ -15,7 +15,7 @@ func (r *Repository) DissolveRoom(
 		UPDATE
 			room
 		SET
-			status = ?
+			status = ?,
 			updated_at = ?
 		WHERE
 			id = ?
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+const (
+	// 読み間違えやすい文字 (0, O, 1, I) を除く
+	codeChars        = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	friendCodeLength = 10
+)
+
+// ユーザーが手入力するコードを生成する
+func newCode(length int) (string, error) {
+	code := make([]byte, length)
+	max := big.NewInt(int64(len(codeChars)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = codeChars[n.Int64()]
+	}
+	return string(code), nil
+}
+
+func newFriendCode() (entity.FriendCodeType, error) {
+	code, err := newCode(friendCodeLength)
+	if err != nil {
+		return "", fmt.Errorf("newFriendCode: %w", err)
+	}
+	return entity.FriendCodeType(code), nil
+}
+
+// フレンドコードが未発行のユーザーに発行する
+//
+// 既に発行済みの場合は何もしないため、呼び出し後に改めてユーザ情報を取得する
+func (r *Repository) IssueUserFriendCode(
+	ctx context.Context, db service.Execer, userId entity.UserId,
+) error {
+	sql := `
+		UPDATE
+			user
+		SET
+			friend_code = ?,
+			updated_at = ?
+		WHERE
+			id = ?
+			AND
+			friend_code IS NULL
+	`
+
+	// フレンドコードが重複した場合は作り直す
+	for trial := 0; trial < 5; trial++ {
+		friendCode, err := newFriendCode()
+		if err != nil {
+			return err
+		}
+
+		_, err = db.ExecContext(ctx, sql, friendCode, r.Clocker.Now(), userId)
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == service.ErrCodeMySQLDuplicateEntry {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("IssueUserFriendCode: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("IssueUserFriendCode: %w", service.ErrAlreadyEntry)
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// userId が参加中 (Leaved 以外) のルームのうち Waiting / LiveStart のものを取得する
+//
+// 再戦ルームが作成済みのルームは終了したものとして扱う
+// 参加中のルームが無い場合は 0 を返す
+func (r *Repository) GetActiveRoomIdOfUser(
+	ctx context.Context,
+	db service.Queryer,
+	userId entity.UserId,
+) (entity.RoomId, error) {
+	var roomId entity.RoomId
+
+	query := `
+	SELECT
+		room.id
+	FROM
+		room_user
+		INNER JOIN room
+			ON
+				room_user.room_id = room.id
+	WHERE
+		room_user.user_id = ?
+		AND
+		room_user.status != ?
+		AND
+		room.status IN (?, ?)
+		AND
+		room.rematch_room_id = 0
+	ORDER BY
+		room.id DESC
+	LIMIT 1
+	;`
+
+	if err := db.GetContext(
+		ctx,
+		&roomId,
+		query,
+		userId,
+		entity.RoomUserStatusLeaved,
+		entity.RoomStatusWaiting,
+		entity.RoomStatusLiveStart,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.RoomId(0), nil
+		}
+		return entity.RoomId(0), fmt.Errorf("GetActiveRoomIdOfUser: %w", err)
+	}
+	return roomId, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// userId -> friendUserId の関係を取得する (存在しない場合は sql.ErrNoRows を wrap したエラーを返す)
+func (r *Repository) GetFriend(
+	ctx context.Context,
+	db service.Queryer,
+	userId entity.UserId,
+	friendUserId entity.UserId,
+) (*entity.Friend, error) {
+	friend := &entity.Friend{}
+
+	sql := `
+	SELECT
+		user_id,
+		friend_user_id,
+		status,
+		created_at,
+		updated_at
+	FROM
+		friend
+	WHERE
+		user_id = ?
+		AND
+		friend_user_id = ?
+	;`
+
+	if err := db.GetContext(
+		ctx,
+		friend,
+		sql,
+		userId,
+		friendUserId,
+	); err != nil {
+		return nil, fmt.Errorf("GetFriend: %w", err)
+	}
+	return friend, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// フレンド・送信中の申請・受信した申請をまとめて取得する
+func (r *Repository) GetFriendList(
+	ctx context.Context,
+	db service.Queryer,
+	userId entity.UserId,
+) ([]*service.FriendListItem, error) {
+	friendList := []*service.FriendListItem{}
+
+	sql := `
+	WITH
+		-- userId から見た相手との関係
+		relation AS (
+			SELECT
+				friend_user_id AS user_id,
+				CASE status
+					WHEN ? THEN ?
+					ELSE ?
+				END AS relation,
+				updated_at
+			FROM
+				friend
+			WHERE
+				user_id = ?
+			UNION ALL
+			SELECT
+				user_id,
+				? AS relation,
+				updated_at
+			FROM
+				friend
+			WHERE
+				friend_user_id = ?
+				AND
+				status = ?
+		)
+	SELECT
+		user.id AS user_id,
+		user.name AS name,
+		user.leader_card_id AS leader_card_id,
+		relation.relation AS relation
+	FROM
+		relation
+		INNER JOIN user
+			ON
+				relation.user_id = user.id
+	ORDER BY
+		relation.relation ASC,
+		relation.updated_at DESC
+	;`
+
+	err := db.SelectContext(
+		ctx,
+		&friendList,
+		sql,
+		entity.FriendStatusAccepted,
+		entity.FriendRelationFriend,
+		entity.FriendRelationOutgoingRequest,
+		userId,
+		entity.FriendRelationIncomingRequest,
+		userId,
+		entity.FriendStatusRequested,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("GetFriendList: %w", err)
+	}
+	return friendList, nil
+}
@@ -21,6 +21,9 @@ func (r *Repository) GetRoom(
 		live_id,
 		host_user_id,
 		status,
+		round,
+		rematch_room_id,
+		start_at,
 		created_at,
 		updated_at
 	FROM
@@ -40,3 +43,41 @@ func (r *Repository) GetRoom(
 	}
 	return room, nil
 }
+
+// GetRoom と同じ内容をトランザクションが終わるまで room の行をロックして取得する
+func (r *Repository) GetRoomForUpdate(
+	ctx context.Context,
+	db service.Queryer,
+	roomId entity.RoomId,
+) (*entity.Room, error) {
+	room := &entity.Room{}
+
+	sql := `
+	SELECT
+		id,
+		live_id,
+		host_user_id,
+		status,
+		round,
+		rematch_room_id,
+		start_at,
+		created_at,
+		updated_at
+	FROM
+		room
+	WHERE
+		id = ?
+	FOR UPDATE
+	;`
+
+	err := db.GetContext(
+		ctx,
+		room,
+		sql,
+		roomId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("GetRoomForUpdate: %w", err)
+	}
+	return room, nil
+}
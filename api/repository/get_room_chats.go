@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// sinceId より後のチャットを古い順に最大 limit 件取得する
+//
+// viewerUserId がブロックしているユーザーのチャットは除く
+func (r *Repository) GetRoomChats(
+	ctx context.Context,
+	db service.Queryer,
+	roomId entity.RoomId,
+	viewerUserId entity.UserId,
+	sinceId entity.RoomChatId,
+	limit int,
+) ([]*entity.RoomChat, error) {
+	chats := []*entity.RoomChat{}
+
+	sql := `
+	SELECT
+		room_chat.id AS id,
+		room_chat.room_id AS room_id,
+		room_chat.user_id AS user_id,
+		room_chat.message AS message,
+		room_chat.stamp_id AS stamp_id,
+		user.name AS user_name,
+		room_chat.created_at AS created_at
+	FROM
+		room_chat
+		INNER JOIN user
+			ON
+				room_chat.user_id = user.id
+	WHERE
+		room_chat.room_id = ?
+		AND
+		room_chat.id > ?
+		AND
+		NOT EXISTS (
+			SELECT
+				1
+			FROM
+				user_block
+			WHERE
+				user_block.user_id = ?
+				AND
+				user_block.blocked_user_id = room_chat.user_id
+		)
+	ORDER BY
+		room_chat.id ASC
+	LIMIT ?
+	;`
+
+	err := db.SelectContext(
+		ctx,
+		&chats,
+		sql,
+		roomId,
+		sinceId,
+		viewerUserId,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("GetRoomChats: %w", err)
+	}
+	return chats, nil
+}
+
+// レート制限のために since 以降にユーザーが送信したチャットの数を数える
+func (r *Repository) CountRoomChatsByUserSince(
+	ctx context.Context,
+	db service.Queryer,
+	roomId entity.RoomId,
+	userId entity.UserId,
+	since time.Time,
+) (int, error) {
+	var count int
+
+	sql := `
+	SELECT
+		COUNT(*)
+	FROM
+		room_chat
+	WHERE
+		room_id = ?
+		AND
+		user_id = ?
+		AND
+		created_at >= ?
+	;`
+
+	if err := db.GetContext(
+		ctx,
+		&count,
+		sql,
+		roomId,
+		userId,
+		since,
+	); err != nil {
+		return 0, fmt.Errorf("CountRoomChatsByUserSince: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+func (r *Repository) GetRoomInvitation(
+	ctx context.Context,
+	db service.Queryer,
+	invitationId entity.RoomInvitationId,
+) (*entity.RoomInvitation, error) {
+	invitation := &entity.RoomInvitation{}
+
+	sql := `
+	SELECT
+		id,
+		room_id,
+		inviter_user_id,
+		invitee_user_id,
+		status,
+		expires_at,
+		created_at,
+		updated_at
+	FROM
+		room_invitation
+	WHERE
+		id = ?
+	;`
+
+	if err := db.GetContext(
+		ctx,
+		invitation,
+		sql,
+		invitationId,
+	); err != nil {
+		return nil, fmt.Errorf("GetRoomInvitation: %w", err)
+	}
+	return invitation, nil
+}
+
+// 受け取った有効な招待 (Pending かつ期限内かつ Waiting のルーム) を新しい順に取得する
+func (r *Repository) GetReceivedRoomInvitations(
+	ctx context.Context,
+	db service.Queryer,
+	inviteeUserId entity.UserId,
+	now time.Time,
+) ([]*service.RoomInvitationItem, error) {
+	invitations := []*service.RoomInvitationItem{}
+
+	sql := `
+	SELECT
+		room_invitation.id AS invitation_id,
+		room_invitation.room_id AS room_id,
+		room.live_id AS live_id,
+		room_invitation.inviter_user_id AS inviter_user_id,
+		user.name AS inviter_name,
+		room_invitation.expires_at AS expires_at
+	FROM
+		room_invitation
+		INNER JOIN room
+			ON
+				room_invitation.room_id = room.id
+		INNER JOIN user
+			ON
+				room_invitation.inviter_user_id = user.id
+	WHERE
+		room_invitation.invitee_user_id = ?
+		AND
+		room_invitation.status = ?
+		AND
+		room_invitation.expires_at > ?
+		AND
+		room.status = ?
+	ORDER BY
+		room_invitation.updated_at DESC
+	;`
+
+	if err := db.SelectContext(
+		ctx,
+		&invitations,
+		sql,
+		inviteeUserId,
+		entity.RoomInvitationStatusPending,
+		now,
+		entity.RoomStatusWaiting,
+	); err != nil {
+		return nil, fmt.Errorf("GetReceivedRoomInvitations: %w", err)
+	}
+	return invitations, nil
+}
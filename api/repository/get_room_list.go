@@ -7,10 +7,12 @@ import (
 	"github.com/pollenjp/gameserver-go/api/service"
 )
 
+// viewerUserId が 0 の場合はブロックによる絞り込みを行わない
 func (r *Repository) GetRoomList(
 	ctx context.Context,
 	db service.Queryer,
 	RoomStatus entity.RoomStatus,
+	viewerUserId entity.UserId,
 ) ([]*service.RoomInfoItem, error) {
 	roomList := []*service.RoomInfoItem{}
 
@@ -28,6 +30,31 @@ func (r *Repository) GetRoomList(
 				room
 			WHERE
 				status = ?
+				AND
+				-- viewerUserId とブロック関係にあるメンバーがいるルームは除く
+				NOT EXISTS (
+					SELECT
+						1
+					FROM
+						room_user AS member_room_user
+						INNER JOIN user_block
+							ON
+								(
+									user_block.user_id = member_room_user.user_id
+									AND
+									user_block.blocked_user_id = ?
+								)
+								OR
+								(
+									user_block.user_id = ?
+									AND
+									user_block.blocked_user_id = member_room_user.user_id
+								)
+					WHERE
+						member_room_user.room_id = room.id
+						AND
+						member_room_user.status != ?
+				)
 		)
 	SELECT
 		filtered_room.id AS "room_id",
@@ -38,6 +65,8 @@ func (r *Repository) GetRoomList(
 		INNER JOIN filtered_room
 			ON
 				room_user.room_id = filtered_room.id
+				AND
+				room_user.role = ?
 	GROUP BY
 		room_id
 	ORDER BY
@@ -49,6 +78,10 @@ func (r *Repository) GetRoomList(
 		&roomList,
 		sql,
 		RoomStatus,
+		viewerUserId,
+		viewerUserId,
+		entity.RoomUserStatusLeaved,
+		entity.RoomUserRolePlayer,
 	)
 	if err != nil {
 		return nil, err
@@ -56,11 +89,13 @@ func (r *Repository) GetRoomList(
 	return roomList, nil
 }
 
+// viewerUserId が 0 の場合はブロックによる絞り込みを行わない
 func (r *Repository) GetRoomListFilteredByLiveId(
 	ctx context.Context,
 	db service.Queryer,
 	RoomStatus entity.RoomStatus,
 	liveId entity.LiveId,
+	viewerUserId entity.UserId,
 ) ([]*service.RoomInfoItem, error) {
 	roomList := []*service.RoomInfoItem{}
 
@@ -80,6 +115,134 @@ func (r *Repository) GetRoomListFilteredByLiveId(
 				live_id = ?
 				AND
 				status = ?
+				AND
+				-- viewerUserId とブロック関係にあるメンバーがいるルームは除く
+				NOT EXISTS (
+					SELECT
+						1
+					FROM
+						room_user AS member_room_user
+						INNER JOIN user_block
+							ON
+								(
+									user_block.user_id = member_room_user.user_id
+									AND
+									user_block.blocked_user_id = ?
+								)
+								OR
+								(
+									user_block.user_id = ?
+									AND
+									user_block.blocked_user_id = member_room_user.user_id
+								)
+					WHERE
+						member_room_user.room_id = room.id
+						AND
+						member_room_user.status != ?
+				)
+		)
+	SELECT
+		filtered_room.id AS "room_id",
+		filtered_room.live_id AS "live_id",
+		COUNT(room_user.user_id) AS "joined_user_count"
+	FROM
+		room_user
+		INNER JOIN filtered_room
+			ON
+				room_user.room_id = filtered_room.id
+				AND
+				room_user.role = ?
+	GROUP BY
+		room_id
+	ORDER BY
+		filtered_room.created_at ASC
+	;`
+
+	err := db.SelectContext(
+		ctx,
+		&roomList,
+		sql,
+		liveId,
+		RoomStatus,
+		viewerUserId,
+		viewerUserId,
+		entity.RoomUserStatusLeaved,
+		entity.RoomUserRolePlayer,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return roomList, nil
+}
+
+// userId のフレンドが host または参加している room を取得する (liveId が 0 の場合は全ての楽曲)
+// userId とブロック関係にあるメンバーがいる room は除く
+func (r *Repository) GetRoomListOfFriends(
+	ctx context.Context,
+	db service.Queryer,
+	RoomStatus entity.RoomStatus,
+	userId entity.UserId,
+	liveId entity.LiveId,
+) ([]*service.RoomInfoItem, error) {
+	roomList := []*service.RoomInfoItem{}
+
+	sql := `
+	WITH
+		-- live_id と room.status とフレンドの参加で絞り込んだ room テーブル
+		filtered_room AS (
+			SELECT
+				room.id,
+				room.live_id,
+				room.created_at
+			FROM
+				room
+			WHERE
+				(? = 0 OR room.live_id = ?)
+				AND
+				room.status = ?
+				AND
+				EXISTS (
+					SELECT
+						1
+					FROM
+						room_user AS friend_room_user
+						INNER JOIN friend
+							ON
+								friend.friend_user_id = friend_room_user.user_id
+					WHERE
+						friend_room_user.room_id = room.id
+						AND
+						friend_room_user.status != ?
+						AND
+						friend.user_id = ?
+						AND
+						friend.status = ?
+				)
+				AND
+				-- viewerUserId とブロック関係にあるメンバーがいるルームは除く
+				NOT EXISTS (
+					SELECT
+						1
+					FROM
+						room_user AS member_room_user
+						INNER JOIN user_block
+							ON
+								(
+									user_block.user_id = member_room_user.user_id
+									AND
+									user_block.blocked_user_id = ?
+								)
+								OR
+								(
+									user_block.user_id = ?
+									AND
+									user_block.blocked_user_id = member_room_user.user_id
+								)
+					WHERE
+						member_room_user.room_id = room.id
+						AND
+						member_room_user.status != ?
+				)
 		)
 	SELECT
 		filtered_room.id AS "room_id",
@@ -90,6 +253,8 @@ func (r *Repository) GetRoomListFilteredByLiveId(
 		INNER JOIN filtered_room
 			ON
 				room_user.room_id = filtered_room.id
+				AND
+				room_user.role = ?
 	GROUP BY
 		room_id
 	ORDER BY
@@ -101,7 +266,15 @@ func (r *Repository) GetRoomListFilteredByLiveId(
 		&roomList,
 		sql,
 		liveId,
+		liveId,
 		RoomStatus,
+		entity.RoomUserStatusLeaved,
+		userId,
+		entity.FriendStatusAccepted,
+		userId,
+		userId,
+		entity.RoomUserStatusLeaved,
+		entity.RoomUserRolePlayer,
 	)
 	if err != nil {
 		return nil, err
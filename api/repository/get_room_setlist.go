@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+func (r *Repository) GetRoomSetlist(
+	ctx context.Context,
+	db service.Queryer,
+	roomId entity.RoomId,
+) ([]*entity.RoomSetlistItem, error) {
+	setlist := []*entity.RoomSetlistItem{}
+
+	sql := `
+	SELECT
+		room_id,
+		round,
+		live_id
+	FROM
+		room_setlist
+	WHERE
+		room_id = ?
+	ORDER BY
+		round ASC
+	;`
+
+	err := db.SelectContext(
+		ctx,
+		&setlist,
+		sql,
+		roomId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("GetRoomSetlist: %w", err)
+	}
+	return setlist, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// ルーム内の全ラウンドのスコアをユーザー毎に合計する
+func (r *Repository) GetRoomStandings(
+	ctx context.Context,
+	db service.Queryer,
+	roomId entity.RoomId,
+) ([]*service.RoomStanding, error) {
+	standings := []*service.RoomStanding{}
+
+	sql := `
+	SELECT
+		user_id,
+		SUM(score) AS total_score,
+		COUNT(round) AS played_rounds
+	FROM
+		score
+	WHERE
+		room_id = ?
+	GROUP BY
+		user_id
+	ORDER BY
+		total_score DESC,
+		user_id ASC
+	;`
+
+	err := db.SelectContext(
+		ctx,
+		&standings,
+		sql,
+		roomId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("GetRoomStandings: %w", err)
+	}
+	return standings, nil
+}
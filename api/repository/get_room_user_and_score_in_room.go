@@ -12,6 +12,7 @@ func (r *Repository) GetRoomUserAndScoreInRoom(
 	ctx context.Context,
 	db service.Queryer,
 	roomId entity.RoomId,
+	round int,
 ) ([]*service.RoomUserAndScore, error) {
 	roomUserAndScoreList := []*service.RoomUserAndScore{}
 
@@ -34,6 +35,10 @@ func (r *Repository) GetRoomUserAndScoreInRoom(
 				room_user.user_id = score.user_id
 	WHERE
 		room_user.room_id = ?
+		AND
+		room_user.role = ?
+		AND
+		score.round = ?
 	ORDER BY
 		room_user.user_id ASC;
 	;`
@@ -43,6 +48,8 @@ func (r *Repository) GetRoomUserAndScoreInRoom(
 		&roomUserAndScoreList,
 		sql,
 		roomId,
+		entity.RoomUserRolePlayer,
+		round,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("GetRoomUserAndScoreInRoom: %w", err)
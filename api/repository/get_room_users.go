@@ -20,7 +20,9 @@ func (r *Repository) GetRoomUsers(
 		room_id,
 		user_id,
 		live_difficulty,
-		status
+		status,
+		rematch,
+		role
 	FROM
 		room_user
 	WHERE
@@ -39,6 +41,43 @@ func (r *Repository) GetRoomUsers(
 	return roomUsers, nil
 }
 
+func (r *Repository) GetRoomUser(
+	ctx context.Context,
+	db service.Queryer,
+	roomId entity.RoomId,
+	userId entity.UserId,
+) (*entity.RoomUser, error) {
+	roomUser := &entity.RoomUser{}
+
+	sql := `
+	SELECT
+		room_id,
+		user_id,
+		live_difficulty,
+		status,
+		rematch,
+		role
+	FROM
+		room_user
+	WHERE
+		room_id = ?
+		AND
+		user_id = ?
+	;`
+
+	err := db.GetContext(
+		ctx,
+		roomUser,
+		sql,
+		roomId,
+		userId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("GetRoomUser: %w", err)
+	}
+	return roomUser, nil
+}
+
 func (r *Repository) GetRoomUsersByStatus(
 	ctx context.Context,
 	db service.Queryer,
@@ -52,11 +91,14 @@ func (r *Repository) GetRoomUsersByStatus(
 		room_id,
 		user_id,
 		live_difficulty,
-		status
+		status,
+		rematch,
+		role
 	FROM
 		room_user
 	WHERE
 		room_id = ?
+		AND
 		status = ?
 	;`
 
@@ -18,6 +18,7 @@ func (r *Repository) GetUserFromId(
 			name,
 			token,
 			leader_card_id,
+			COALESCE(friend_code, '') AS friend_code,
 			created_at,
 			updated_at
 		FROM user
@@ -32,22 +33,24 @@ func (r *Repository) GetUserFromId(
 	return u, nil
 }
 
-// DB内のユーザ情報を取得
-func (r *Repository) GetUserFromToken(
-	ctx context.Context, db service.Queryer, userToken entity.UserTokenType,
+// フレンドコードからユーザ情報を取得
+func (r *Repository) GetUserFromFriendCode(
+	ctx context.Context, db service.Queryer, friendCode entity.FriendCodeType,
 ) (*entity.User, error) {
 	u := &entity.User{}
-	sql := `SELECT
+	sql := `
+		SELECT
 			id,
 			name,
 			token,
 			leader_card_id,
+			COALESCE(friend_code, '') AS friend_code,
 			created_at,
 			updated_at
 		FROM user
-		WHERE token = ?
+		WHERE friend_code = ?
 	`
-	if err := db.GetContext(ctx, u, sql, userToken); err != nil {
+	if err := db.GetContext(ctx, u, sql, friendCode); err != nil {
 		return nil, err
 	}
 	if err := u.ValidateNotEmpty(); err != nil {
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// blockedUserId をブロックしているユーザーの一覧を取得する
+func (r *Repository) GetBlockerUserIds(
+	ctx context.Context,
+	db service.Queryer,
+	blockedUserId entity.UserId,
+) ([]entity.UserId, error) {
+	userIds := []entity.UserId{}
+
+	sql := `
+	SELECT
+		user_id
+	FROM
+		user_block
+	WHERE
+		blocked_user_id = ?
+	;`
+
+	if err := db.SelectContext(
+		ctx,
+		&userIds,
+		sql,
+		blockedUserId,
+	); err != nil {
+		return nil, fmt.Errorf("GetBlockerUserIds: %w", err)
+	}
+	return userIds, nil
+}
+
+// userId がブロックしているユーザーの一覧を新しい順に取得する
+func (r *Repository) GetBlockList(
+	ctx context.Context,
+	db service.Queryer,
+	userId entity.UserId,
+) ([]*service.BlockListItem, error) {
+	blockList := []*service.BlockListItem{}
+
+	sql := `
+	SELECT
+		user.id AS user_id,
+		user.name AS name,
+		user_block.created_at AS blocked_at
+	FROM
+		user_block
+		INNER JOIN user
+			ON
+				user_block.blocked_user_id = user.id
+	WHERE
+		user_block.user_id = ?
+	ORDER BY
+		user_block.created_at DESC
+	;`
+
+	if err := db.SelectContext(
+		ctx,
+		&blockList,
+		sql,
+		userId,
+	); err != nil {
+		return nil, fmt.Errorf("GetBlockList: %w", err)
+	}
+	return blockList, nil
+}
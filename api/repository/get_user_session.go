@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// 認証トークンからセッションを取得する (期限切れ・無効化済みのものも返す)
+//
+// ハッシュで検索するため、平文で保存されているトークンは MigrateLegacyUserToken で移行する
+func (r *Repository) GetUserSessionFromToken(
+	ctx context.Context,
+	db service.Queryer,
+	token entity.UserTokenType,
+) (*entity.UserSession, error) {
+	session := &entity.UserSession{}
+
+	sql := `
+	SELECT
+		id,
+		user_id,
+		issued_at,
+		expires_at,
+		revoked_at
+	FROM
+		user_session
+	WHERE
+		token = ?
+	;`
+
+	if err := db.GetContext(ctx, session, sql, r.hashToken(token)); err != nil {
+		return nil, fmt.Errorf("GetUserSessionFromToken: %w", err)
+	}
+	session.Token = token
+	return session, nil
+}
@@ -19,7 +19,8 @@ func (r *Repository) GetRoomUsersWaiting(
 		user.id AS "user_id",
 		user.name AS "name",
 		user.leader_card_id AS "leader_card_id",
-		room_user.live_difficulty AS "select_difficulty"
+		room_user.live_difficulty AS "select_difficulty",
+		room_user.role = ? AS "is_spectator"
 	FROM
 		room_user
 		INNER JOIN user
@@ -27,6 +28,7 @@ func (r *Repository) GetRoomUsersWaiting(
 				room_user.user_id = user.id
 	WHERE
 		room_user.room_id = ?
+		AND
 		room_user.status = ?
 	;`
 
@@ -34,6 +36,7 @@ func (r *Repository) GetRoomUsersWaiting(
 		ctx,
 		&waitingRoomUser,
 		sql,
+		entity.RoomUserRoleSpectator,
 		roomId,
 		entity.RoomUserStatusWaiting,
 	)
@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// DB には Idempotency-Key の HMAC のみ保存する
+func (r *Repository) hashIdempotencyKey(key entity.IdempotencyKeyType) string {
+	mac := hmac.New(sha256.New, []byte(r.TokenPepper))
+	mac.Write([]byte("idempotency-key:"))
+	mac.Write([]byte(key))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// レスポンスには認証トークンなどが含まれるため、Idempotency-Key から導出した鍵で暗号化して保存する
+// (DB の内容だけでは復号できない)
+func (r *Repository) idempotencyBodyCipher(key entity.IdempotencyKeyType) (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, []byte(r.TokenPepper))
+	mac.Write([]byte("idempotency-body:"))
+	mac.Write([]byte(key))
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (r *Repository) encryptIdempotencyBody(key entity.IdempotencyKeyType, body []byte) ([]byte, error) {
+	aead, err := r.idempotencyBodyCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, body, nil), nil
+}
+
+func (r *Repository) decryptIdempotencyBody(key entity.IdempotencyKeyType, encrypted []byte) ([]byte, error) {
+	aead, err := r.idempotencyBodyCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted) < aead.NonceSize() {
+		return nil, errors.New("encrypted body is too short")
+	}
+	nonce, ciphertext := encrypted[:aead.NonceSize()], encrypted[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
+
+// 処理中のレコードを作成する (既に存在する場合は service.ErrAlreadyEntry を返す)
+func (r *Repository) CreateIdempotencyRecord(
+	ctx context.Context,
+	db service.Execer,
+	record *entity.IdempotencyRecord,
+) error {
+	record.CreatedAt = r.Clocker.Now()
+
+	query := `
+	INSERT INTO
+		idempotency_key
+		(
+			user_id,
+			idempotency_key,
+			request_hash,
+			locked_until,
+			expires_at,
+			created_at
+		)
+	VALUES
+		(?, ?, ?, ?, ?, ?)
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		query,
+		record.UserId,
+		r.hashIdempotencyKey(record.Key),
+		record.RequestHash,
+		record.LockedUntil,
+		record.ExpiresAt,
+		record.CreatedAt,
+	); err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == service.ErrCodeMySQLDuplicateEntry {
+			return fmt.Errorf("CreateIdempotencyRecord: %w", service.ErrAlreadyEntry)
+		}
+		return fmt.Errorf("CreateIdempotencyRecord: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetIdempotencyRecord(
+	ctx context.Context,
+	db service.Queryer,
+	userId entity.UserId,
+	key entity.IdempotencyKeyType,
+) (*entity.IdempotencyRecord, error) {
+	record := &entity.IdempotencyRecord{}
+
+	query := `
+	SELECT
+		user_id,
+		request_hash,
+		status_code,
+		response_body,
+		locked_until,
+		expires_at,
+		created_at
+	FROM
+		idempotency_key
+	WHERE
+		user_id = ?
+		AND
+		idempotency_key = ?
+	;`
+
+	if err := db.GetContext(ctx, record, query, userId, r.hashIdempotencyKey(key)); err != nil {
+		return nil, fmt.Errorf("GetIdempotencyRecord: %w", err)
+	}
+	record.Key = key
+	if record.ResponseBody != nil {
+		body, err := r.decryptIdempotencyBody(key, record.ResponseBody)
+		if err != nil {
+			return nil, fmt.Errorf("GetIdempotencyRecord: decrypting response body: %w", err)
+		}
+		record.ResponseBody = body
+	}
+	return record, nil
+}
+
+// 処理中のレコードのロックを延長する (処理が終わったものは変更しない)
+func (r *Repository) RenewIdempotencyRecord(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+	key entity.IdempotencyKeyType,
+	lockedUntil time.Time,
+) error {
+	query := `
+	UPDATE
+		idempotency_key
+	SET
+		locked_until = ?
+	WHERE
+		user_id = ?
+		AND
+		idempotency_key = ?
+		AND
+		status_code = 0
+	;`
+
+	if _, err := db.ExecContext(ctx, query, lockedUntil, userId, r.hashIdempotencyKey(key)); err != nil {
+		return fmt.Errorf("RenewIdempotencyRecord: %w", err)
+	}
+	return nil
+}
+
+// 処理が終わったレコードにレスポンスを保存する
+func (r *Repository) CompleteIdempotencyRecord(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+	key entity.IdempotencyKeyType,
+	statusCode int,
+	responseBody []byte,
+) error {
+	encrypted, err := r.encryptIdempotencyBody(key, responseBody)
+	if err != nil {
+		return fmt.Errorf("CompleteIdempotencyRecord: encrypting response body: %w", err)
+	}
+
+	query := `
+	UPDATE
+		idempotency_key
+	SET
+		status_code = ?,
+		response_body = ?
+	WHERE
+		user_id = ?
+		AND
+		idempotency_key = ?
+	;`
+
+	if _, err := db.ExecContext(ctx, query, statusCode, encrypted, userId, r.hashIdempotencyKey(key)); err != nil {
+		return fmt.Errorf("CompleteIdempotencyRecord: %w", err)
+	}
+	return nil
+}
+
+// 該当するレコードが無い場合は sql.ErrNoRows を返す
+func (r *Repository) DeleteIdempotencyRecord(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+	key entity.IdempotencyKeyType,
+) error {
+	query := `
+	DELETE FROM
+		idempotency_key
+	WHERE
+		user_id = ?
+		AND
+		idempotency_key = ?
+	;`
+
+	result, err := db.ExecContext(ctx, query, userId, r.hashIdempotencyKey(key))
+	if err != nil {
+		return fmt.Errorf("DeleteIdempotencyRecord: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("DeleteIdempotencyRecord: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("DeleteIdempotencyRecord: %w", sql.ErrNoRows)
+	}
+	return nil
+}
+
+// 期限切れのレコードを最大 limit 件削除し、削除した件数を返す
+func (r *Repository) DeleteExpiredIdempotencyRecords(
+	ctx context.Context,
+	db service.Execer,
+	now time.Time,
+	limit int,
+) (int64, error) {
+	query := `
+	DELETE FROM
+		idempotency_key
+	WHERE
+		expires_at < ?
+	LIMIT ?
+	;`
+
+	result, err := db.ExecContext(ctx, query, now, limit)
+	if err != nil {
+		return 0, fmt.Errorf("DeleteExpiredIdempotencyRecords: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("DeleteExpiredIdempotencyRecords: %w", err)
+	}
+	return n, nil
+}
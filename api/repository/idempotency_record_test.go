@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pollenjp/gameserver-go/api/clock"
+)
+
+// 実行したクエリを記録する service.Execer
+type recordingExecer struct {
+	query        string
+	args         []any
+	rowsAffected int64
+}
+
+func (e *recordingExecer) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	e.query = query
+	e.args = args
+	return rowsAffectedResult(e.rowsAffected), nil
+}
+
+func (e *recordingExecer) NamedExecContext(_ context.Context, query string, _ interface{}) (sql.Result, error) {
+	e.query = query
+	return rowsAffectedResult(e.rowsAffected), nil
+}
+
+type rowsAffectedResult int64
+
+func (r rowsAffectedResult) LastInsertId() (int64, error) {
+	return 0, nil
+}
+
+func (r rowsAffectedResult) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
+
+func TestDeleteExpiredIdempotencyRecords(t *testing.T) {
+	t.Parallel()
+
+	now := clock.FixedClocker{}.Now()
+	db := &recordingExecer{rowsAffected: 3}
+	r := &Repository{Clocker: clock.FixedClocker{}}
+
+	got, err := r.DeleteExpiredIdempotencyRecords(context.Background(), db, now, 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 3 {
+		t.Errorf("want 3 deleted, but got %d", got)
+	}
+
+	// expires_at のインデックスを使い、1 回に削除する件数を制限する
+	query := strings.Join(strings.Fields(db.query), " ")
+	if want := "DELETE FROM idempotency_key WHERE expires_at < ? LIMIT ? ;"; query != want {
+		t.Errorf("want query %q, but got %q", want, query)
+	}
+	if d := cmp.Diff([]any{now, 100}, db.args, cmp.Comparer(func(x, y time.Time) bool { return x.Equal(y) })); d != "" {
+		t.Errorf("args differ (-want +got):\n%s", d)
+	}
+}
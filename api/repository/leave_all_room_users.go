@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// ルームの全員 (観戦者を含む) の RoomUser.Status を Leaved にする
+func (r *Repository) LeaveAllRoomUsers(
+	ctx context.Context,
+	db service.Execer,
+	roomId entity.RoomId,
+) error {
+	sql := `
+	UPDATE
+		room_user
+	SET
+		status = ?
+	WHERE
+		room_id = ?
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		entity.RoomUserStatusLeaved,
+		roomId,
+	); err != nil {
+		return fmt.Errorf("LeaveAllRoomUsers: %w", err)
+	}
+	return nil
+}
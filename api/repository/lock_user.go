@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// トランザクションが終わるまで user の行をロックする (同じユーザーの操作を直列化する)
+//
+// ユーザーが存在しない場合は sql.ErrNoRows を wrap したエラーを返す
+func (r *Repository) LockUser(
+	ctx context.Context,
+	db service.Queryer,
+	userId entity.UserId,
+) error {
+	var id entity.UserId
+	if err := db.GetContext(
+		ctx,
+		&id,
+		`SELECT id FROM user WHERE id = ? FOR UPDATE;`,
+		userId,
+	); err != nil {
+		return fmt.Errorf("LockUser: %w", err)
+	}
+	return nil
+}
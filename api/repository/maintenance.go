@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// メンテナンスモードの行は id = 1 の 1 行のみ
+const maintenanceId = 1
+
+// 行が無い場合は sql.ErrNoRows を返す
+func (r *Repository) GetMaintenance(
+	ctx context.Context,
+	db service.Queryer,
+) (*entity.Maintenance, error) {
+	m := &entity.Maintenance{}
+
+	sql := `
+	SELECT
+		enabled,
+		message,
+		ends_at,
+		admin_name,
+		updated_at
+	FROM
+		maintenance
+	WHERE
+		id = ?
+	;`
+
+	if err := db.GetContext(ctx, m, sql, maintenanceId); err != nil {
+		return nil, fmt.Errorf("GetMaintenance: %w", err)
+	}
+	return m, nil
+}
+
+func (r *Repository) SetMaintenance(
+	ctx context.Context,
+	db service.Execer,
+	m *entity.Maintenance,
+) error {
+	m.UpdatedAt = r.Clocker.Now()
+
+	sql := `
+	INSERT INTO
+		maintenance
+		(
+			id,
+			enabled,
+			message,
+			ends_at,
+			admin_name,
+			updated_at
+		)
+	VALUES
+		(?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		enabled = VALUES(enabled),
+		message = VALUES(message),
+		ends_at = VALUES(ends_at),
+		admin_name = VALUES(admin_name),
+		updated_at = VALUES(updated_at)
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		maintenanceId,
+		m.Enabled,
+		m.Message,
+		m.EndsAt,
+		m.AdminName,
+		m.UpdatedAt,
+	); err != nil {
+		return fmt.Errorf("SetMaintenance: %w", err)
+	}
+	return nil
+}
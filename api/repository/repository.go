@@ -90,4 +90,6 @@ func New(ctx context.Context, cfg *config.Config) (*sqlx.DB, func(), error) {
 // Repository はデータベースへのアクセスを提供する
 type Repository struct {
 	Clocker clock.Clocker
+	// 認証トークンのハッシュに使う鍵 (DB とは別に管理する)
+	TokenPepper string
 }
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+func (r *Repository) RevokeUserSession(
+	ctx context.Context,
+	db service.Execer,
+	sessionId entity.UserSessionId,
+) error {
+	sql := `
+	UPDATE
+		user_session
+	SET
+		revoked_at = ?
+	WHERE
+		id = ?
+		AND
+		revoked_at IS NULL
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		r.Clocker.Now(),
+		sessionId,
+	); err != nil {
+		return fmt.Errorf("RevokeUserSession: %w", err)
+	}
+	return nil
+}
+
+// ユーザーの全てのセッションを無効化する
+func (r *Repository) RevokeAllUserSessions(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+) error {
+	sql := `
+	UPDATE
+		user_session
+	SET
+		revoked_at = ?
+	WHERE
+		user_id = ?
+		AND
+		revoked_at IS NULL
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		r.Clocker.Now(),
+		userId,
+	); err != nil {
+		return fmt.Errorf("RevokeAllUserSessions: %w", err)
+	}
+	return nil
+}
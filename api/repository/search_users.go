@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// 名前の前方一致でユーザーを検索する (id の昇順で最大 limit 件)
+func (r *Repository) SearchUsersByName(
+	ctx context.Context,
+	db service.Queryer,
+	namePrefix string,
+	limit int,
+) ([]*entity.User, error) {
+	users := []*entity.User{}
+
+	// LIKE のワイルドカードとして扱わないようにエスケープする
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(namePrefix)
+
+	sql := `
+	SELECT
+		id,
+		name,
+		token,
+		leader_card_id,
+		COALESCE(friend_code, '') AS friend_code,
+		created_at,
+		updated_at
+	FROM
+		user
+	WHERE
+		name LIKE ?
+	ORDER BY
+		id ASC
+	LIMIT ?
+	;`
+
+	if err := db.SelectContext(ctx, &users, sql, escaped+"%", limit); err != nil {
+		return nil, fmt.Errorf("SearchUsersByName: %w", err)
+	}
+	return users, nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// room.status を LiveStart にし、ライブの開始時刻を設定する
+func (r *Repository) StartRoomLive(
+	ctx context.Context,
+	db service.Execer,
+	roomId entity.RoomId,
+	startAt time.Time,
+) error {
+	sql := `
+	UPDATE
+		room
+	SET
+		status = ?,
+		start_at = ?,
+		updated_at = ?
+	WHERE
+		id = ?
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		entity.RoomStatusLiveStart,
+		startAt,
+		r.Clocker.Now(),
+		roomId,
+	); err != nil {
+		return fmt.Errorf("StartRoomLive: %w", err)
+	}
+
+	return nil
+}
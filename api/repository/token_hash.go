@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// DB に保存するトークンのハッシュには prefix を付け、平文で保存されていた頃のトークンと区別する
+const tokenHashPrefix = "hmac-sha256:"
+
+// 認証トークンを server-side pepper (config.Config.TokenPepper) を鍵とした HMAC に変換する
+func (r *Repository) hashToken(token entity.UserTokenType) string {
+	mac := hmac.New(sha256.New, []byte(r.TokenPepper))
+	mac.Write([]byte(token))
+	return tokenHashPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// 平文で保存されているトークンをハッシュに置き換え、そのトークンのセッションを返す
+//
+// - user_session に平文で保存されているもの (セッションの期限・無効化はそのまま引き継ぐ)
+// - セッション導入前のユーザー (user.token のみ) は新しいセッションを作成する
+// どちらにも該当しない場合は sql.ErrNoRows を wrap したエラーを返す
+//
+// 同じトークンでの同時の認証を直列化するため行をロックする (トランザクション内で呼ぶ)
+// 先に移行された場合は移行済みのセッションを返す
+func (r *Repository) MigrateLegacyUserToken(
+	ctx context.Context,
+	db service.QueryerAndExecer,
+	token entity.UserTokenType,
+) (*entity.UserSession, error) {
+	hashed := r.hashToken(token)
+
+	// prefix 付きの値 (ハッシュ) をそのままトークンとして使えないように、平文とハッシュのどちらかに一致するものを探す
+	// user -> user_session の順にロックする
+	var user struct {
+		Id    entity.UserId `db:"id"`
+		Token string        `db:"token"`
+	}
+	userErr := db.GetContext(
+		ctx,
+		&user,
+		`SELECT id, token FROM user WHERE token IN (?, ?) FOR UPDATE;`,
+		string(token),
+		hashed,
+	)
+	if userErr != nil && !errors.Is(userErr, sql.ErrNoRows) {
+		return nil, fmt.Errorf("MigrateLegacyUserToken: %w", userErr)
+	}
+	if userErr == nil && user.Token != hashed {
+		if _, err := db.ExecContext(
+			ctx,
+			`UPDATE user SET token = ? WHERE id = ?;`,
+			hashed,
+			user.Id,
+		); err != nil {
+			return nil, fmt.Errorf("MigrateLegacyUserToken: %w", err)
+		}
+	}
+
+	var session struct {
+		entity.UserSession
+		StoredToken string `db:"token"`
+	}
+	err := db.GetContext(
+		ctx,
+		&session,
+		`
+		SELECT
+			id,
+			user_id,
+			token,
+			issued_at,
+			expires_at,
+			revoked_at
+		FROM
+			user_session
+		WHERE
+			token IN (?, ?)
+		FOR UPDATE
+		;`,
+		string(token),
+		hashed,
+	)
+	switch {
+	case err == nil:
+		if session.StoredToken != hashed {
+			if _, err := db.ExecContext(
+				ctx,
+				`UPDATE user_session SET token = ? WHERE id = ?;`,
+				hashed,
+				session.Id,
+			); err != nil {
+				return nil, fmt.Errorf("MigrateLegacyUserToken: %w", err)
+			}
+		}
+		session.Token = token
+		return &session.UserSession, nil
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("MigrateLegacyUserToken: %w", err)
+	}
+
+	// セッションが無いのはセッション導入前のユーザーのみ
+	if userErr != nil || user.Token == hashed {
+		return nil, fmt.Errorf("MigrateLegacyUserToken: %w", sql.ErrNoRows)
+	}
+	return r.CreateUserSession(ctx, db, user.Id, token)
+}
+
+// user.token を誰も知らないトークンのハッシュに置き換える
+//
+// 平文で保存されている古いトークンが MigrateLegacyUserToken で新しいセッションに移行されないようにする
+func (r *Repository) RotateUserToken(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+) error {
+	if _, err := db.ExecContext(
+		ctx,
+		`UPDATE user SET token = ? WHERE id = ?;`,
+		r.hashToken(entity.UserTokenType(uuid.NewString())),
+		userId,
+	); err != nil {
+		return fmt.Errorf("RotateUserToken: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// Pending の招待のみ更新する (既に回答済みの場合は sql.ErrNoRows を返す)
+//
+// 同時に承諾・拒否した場合は先に更新した方のみ成功する
+func (r *Repository) UpdateRoomInvitationStatus(
+	ctx context.Context,
+	db service.Execer,
+	invitationId entity.RoomInvitationId,
+	status entity.RoomInvitationStatus,
+) error {
+	query := `
+	UPDATE
+		room_invitation
+	SET
+		status = ?,
+		updated_at = ?
+	WHERE
+		id = ?
+		AND
+		status = ?
+	;`
+
+	result, err := db.ExecContext(
+		ctx,
+		query,
+		status,
+		r.Clocker.Now(),
+		invitationId,
+		entity.RoomInvitationStatusPending,
+	)
+	if err != nil {
+		return fmt.Errorf("UpdateRoomInvitationStatus: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("UpdateRoomInvitationStatus: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("UpdateRoomInvitationStatus: %w", sql.ErrNoRows)
+	}
+	return nil
+}
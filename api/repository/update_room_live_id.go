@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// room.live_id を更新する.
+// `/room/wait` で変更を検知できるように updated_at も更新する.
+func (r *Repository) UpdateRoomLiveId(
+	ctx context.Context,
+	db service.Execer,
+	roomId entity.RoomId,
+	liveId entity.LiveId,
+) error {
+	sql := `
+	UPDATE
+		room
+	SET
+		live_id = ?,
+		updated_at = ?
+	WHERE
+		id = ?
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		liveId,
+		r.Clocker.Now(),
+		roomId,
+	); err != nil {
+		return fmt.Errorf("UpdateRoomLiveId: %w", err)
+	}
+
+	return nil
+}
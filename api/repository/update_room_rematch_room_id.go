@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// `/room/wait` で再戦ルームの作成を検知できるように updated_at も更新する.
+func (r *Repository) UpdateRoomRematchRoomId(
+	ctx context.Context,
+	db service.Execer,
+	roomId entity.RoomId,
+	rematchRoomId entity.RoomId,
+) error {
+	sql := `
+	UPDATE
+		room
+	SET
+		rematch_room_id = ?,
+		updated_at = ?
+	WHERE
+		id = ?
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		rematchRoomId,
+		r.Clocker.Now(),
+		roomId,
+	); err != nil {
+		return fmt.Errorf("UpdateRoomRematchRoomId: %w", err)
+	}
+
+	return nil
+}
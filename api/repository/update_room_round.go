@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// 次のラウンドへ進める
+//
+// - room.round, room.live_id を更新する
+// - room.status を Waiting に戻し、 room.start_at をクリアする
+func (r *Repository) UpdateRoomRound(
+	ctx context.Context,
+	db service.Execer,
+	roomId entity.RoomId,
+	round int,
+	liveId entity.LiveId,
+) error {
+	sql := `
+	UPDATE
+		room
+	SET
+		round = ?,
+		live_id = ?,
+		status = ?,
+		start_at = NULL,
+		updated_at = ?
+	WHERE
+		id = ?
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		round,
+		liveId,
+		entity.RoomStatusWaiting,
+		r.Clocker.Now(),
+		roomId,
+	); err != nil {
+		return fmt.Errorf("UpdateRoomRound: %w", err)
+	}
+
+	return nil
+}
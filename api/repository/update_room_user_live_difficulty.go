@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+func (r *Repository) UpdateRoomUserLiveDifficulty(
+	ctx context.Context,
+	db service.Execer,
+	roomId entity.RoomId,
+	userId entity.UserId,
+	liveDifficulty entity.LiveDifficulty,
+) error {
+	sql := `
+	UPDATE
+		room_user
+	SET
+		live_difficulty = ?
+	WHERE
+		room_id = ?
+		AND
+		user_id = ?
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		liveDifficulty,
+		roomId,
+		userId,
+	); err != nil {
+		return fmt.Errorf("UpdateRoomUserLiveDifficulty: %w", err)
+	}
+
+	return nil
+}
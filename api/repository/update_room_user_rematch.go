@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+func (r *Repository) UpdateRoomUserRematch(
+	ctx context.Context,
+	db service.Execer,
+	roomId entity.RoomId,
+	userId entity.UserId,
+	rematch bool,
+) error {
+	sql := `
+	UPDATE
+		room_user
+	SET
+		rematch = ?
+	WHERE
+		room_id = ?
+		AND
+		user_id = ?
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		rematch,
+		roomId,
+		userId,
+	); err != nil {
+		return fmt.Errorf("UpdateRoomUserRematch: %w", err)
+	}
+
+	return nil
+}
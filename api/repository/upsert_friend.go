@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+// userId -> friendUserId の関係を登録する (既に存在する場合は status を更新する)
+func (r *Repository) UpsertFriend(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+	friendUserId entity.UserId,
+	status entity.FriendStatus,
+) (*entity.Friend, error) {
+	friend := &entity.Friend{
+		UserId:       userId,
+		FriendUserId: friendUserId,
+		Status:       status,
+		CreatedAt:    r.Clocker.Now(),
+		UpdatedAt:    r.Clocker.Now(),
+	}
+
+	sql := `
+	INSERT INTO
+		friend
+		(
+			user_id,
+			friend_user_id,
+			status,
+			created_at,
+			updated_at
+		)
+	VALUES
+		(?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		status = VALUES(status),
+		updated_at = VALUES(updated_at)
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		friend.UserId,
+		friend.FriendUserId,
+		friend.Status,
+		friend.CreatedAt,
+		friend.UpdatedAt,
+	); err != nil {
+		return nil, fmt.Errorf("UpsertFriend: %w", err)
+	}
+	return friend, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+func (r *Repository) CreateUserBan(
+	ctx context.Context,
+	db service.Execer,
+	ban *entity.UserBan,
+) error {
+	ban.CreatedAt = r.Clocker.Now()
+
+	sql := `
+	INSERT INTO
+		user_ban
+		(
+			user_id,
+			reason,
+			starts_at,
+			ends_at,
+			admin_name,
+			created_at
+		)
+	VALUES
+		(?, ?, ?, ?, ?, ?)
+	;`
+
+	result, err := db.ExecContext(
+		ctx,
+		sql,
+		ban.UserId,
+		ban.Reason,
+		ban.StartsAt,
+		ban.EndsAt,
+		ban.AdminName,
+		ban.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("CreateUserBan: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("CreateUserBan: %w", err)
+	}
+	ban.Id = entity.UserBanId(id)
+	return nil
+}
+
+// now の時点で有効な BAN のうち最も長く続くものを返す (無い場合は nil)
+func (r *Repository) GetActiveUserBan(
+	ctx context.Context,
+	db service.Queryer,
+	userId entity.UserId,
+	now time.Time,
+) (*entity.UserBan, error) {
+	bans := []*entity.UserBan{}
+
+	sql := `
+	SELECT
+		id,
+		user_id,
+		reason,
+		starts_at,
+		ends_at,
+		lifted_at,
+		admin_name,
+		created_at
+	FROM
+		user_ban
+	WHERE
+		user_id = ?
+		AND
+		lifted_at IS NULL
+		AND
+		starts_at <= ?
+		AND
+		(ends_at IS NULL OR ends_at > ?)
+	ORDER BY
+		ends_at IS NULL DESC,
+		ends_at DESC
+	LIMIT 1
+	;`
+
+	if err := db.SelectContext(ctx, &bans, sql, userId, now, now); err != nil {
+		return nil, fmt.Errorf("GetActiveUserBan: %w", err)
+	}
+	if len(bans) == 0 {
+		return nil, nil
+	}
+	return bans[0], nil
+}
+
+// 解除されていない BAN を全て解除する
+func (r *Repository) LiftUserBans(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+) error {
+	sql := `
+	UPDATE
+		user_ban
+	SET
+		lifted_at = ?
+	WHERE
+		user_id = ?
+		AND
+		lifted_at IS NULL
+	;`
+
+	if _, err := db.ExecContext(ctx, sql, r.Clocker.Now(), userId); err != nil {
+		return fmt.Errorf("LiftUserBans: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/service"
+)
+
+const transferIdLength = 12
+
+// 引き継ぎコードを発行する (発行済みの場合は上書きする)
+func (r *Repository) IssueUserTransfer(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+	passwordHash string,
+	expiresAt time.Time,
+) (*entity.UserTransfer, error) {
+	sql := `
+	INSERT INTO
+		user_transfer
+		(
+			user_id,
+			transfer_id,
+			password_hash,
+			expires_at,
+			failed_count,
+			locked_until,
+			created_at
+		)
+	VALUES
+		(?, ?, ?, ?, 0, NULL, ?)
+	ON DUPLICATE KEY UPDATE
+		transfer_id = VALUES(transfer_id),
+		password_hash = VALUES(password_hash),
+		expires_at = VALUES(expires_at),
+		failed_count = 0,
+		locked_until = NULL,
+		created_at = VALUES(created_at)
+	;`
+
+	// 引き継ぎコードが重複した場合は作り直す
+	for trial := 0; trial < 5; trial++ {
+		code, err := newCode(transferIdLength)
+		if err != nil {
+			return nil, fmt.Errorf("IssueUserTransfer: %w", err)
+		}
+		transfer := &entity.UserTransfer{
+			UserId:       userId,
+			TransferId:   entity.TransferIdType(code),
+			PasswordHash: passwordHash,
+			ExpiresAt:    expiresAt,
+			CreatedAt:    r.Clocker.Now(),
+		}
+
+		_, err = db.ExecContext(
+			ctx,
+			sql,
+			transfer.UserId,
+			transfer.TransferId,
+			transfer.PasswordHash,
+			transfer.ExpiresAt,
+			transfer.CreatedAt,
+		)
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == service.ErrCodeMySQLDuplicateEntry {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("IssueUserTransfer: %w", err)
+		}
+		return transfer, nil
+	}
+	return nil, fmt.Errorf("IssueUserTransfer: %w", service.ErrAlreadyEntry)
+}
+
+// 存在しない場合は sql.ErrNoRows を wrap したエラーを返す
+//
+// 失敗回数の更新が同時のリクエストで上書きされないように行をロックするため、トランザクション内で呼ぶ
+func (r *Repository) GetUserTransfer(
+	ctx context.Context,
+	db service.Queryer,
+	transferId entity.TransferIdType,
+) (*entity.UserTransfer, error) {
+	transfer := &entity.UserTransfer{}
+
+	sql := `
+	SELECT
+		user_id,
+		transfer_id,
+		password_hash,
+		expires_at,
+		failed_count,
+		locked_until,
+		created_at
+	FROM
+		user_transfer
+	WHERE
+		transfer_id = ?
+	FOR UPDATE
+	;`
+
+	if err := db.GetContext(ctx, transfer, sql, transferId); err != nil {
+		return nil, fmt.Errorf("GetUserTransfer: %w", err)
+	}
+	return transfer, nil
+}
+
+// パスワードの失敗回数とロックを更新する
+func (r *Repository) UpdateUserTransferFailure(
+	ctx context.Context,
+	db service.Execer,
+	userId entity.UserId,
+	failedCount int,
+	lockedUntil *time.Time,
+) error {
+	sql := `
+	UPDATE
+		user_transfer
+	SET
+		failed_count = ?,
+		locked_until = ?
+	WHERE
+		user_id = ?
+	;`
+
+	if _, err := db.ExecContext(
+		ctx,
+		sql,
+		failedCount,
+		lockedUntil,
+		userId,
+	); err != nil {
+		return fmt.Errorf("UpdateUserTransferFailure: %w", err)
+	}
+	return nil
+}
+
+// 引き継ぎコードを削除する (既に削除されていた場合は sql.ErrNoRows を wrap したエラーを返す)
+func (r *Repository) DeleteUserTransfer(
+	ctx context.Context,
+	db service.Execer,
+	transferId entity.TransferIdType,
+) error {
+	result, err := db.ExecContext(
+		ctx,
+		`DELETE FROM user_transfer WHERE transfer_id = ?;`,
+		transferId,
+	)
+	if err != nil {
+		return fmt.Errorf("DeleteUserTransfer: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("DeleteUserTransfer: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("DeleteUserTransfer: %w", sql.ErrNoRows)
+	}
+	return nil
+}
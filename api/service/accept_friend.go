@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// TODO: convert to //go:generate when writing tests
+type AcceptFriendRepository interface {
+	GetFriend(
+		ctx context.Context,
+		db Queryer,
+		userId entity.UserId,
+		friendUserId entity.UserId,
+	) (*entity.Friend, error)
+	UpsertFriend(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+		friendUserId entity.UserId,
+		status entity.FriendStatus,
+	) (*entity.Friend, error)
+}
+
+type AcceptFriend struct {
+	DB   Beginner
+	Repo AcceptFriendRepository
+}
+
+// requesterId から受けたフレンド申請を承認する
+func (af *AcceptFriend) AcceptFriend(
+	ctx context.Context,
+	userId entity.UserId,
+	requesterId entity.UserId,
+) error {
+	// helper functions
+	fail := func(err error) error {
+		return err
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) error {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	tx, err := af.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	request, err := af.Repo.GetFriend(ctx, tx, requesterId, userId)
+	if err != nil {
+		return failWithRollBack(tx, fmt.Errorf("friend request not found: %w", err))
+	}
+	if request.Status == entity.FriendStatusAccepted {
+		// 既にフレンド
+		return failWithRollBack(tx, nil)
+	}
+
+	if _, err := af.Repo.UpsertFriend(ctx, tx, requesterId, userId, entity.FriendStatusAccepted); err != nil {
+		return failWithRollBack(tx, err)
+	}
+	if _, err := af.Repo.UpsertFriend(ctx, tx, userId, requesterId, entity.FriendStatusAccepted); err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+//go:generate go run github.com/matryer/moq -out active_room_moq_test.go . ActiveRoomRepository
+type ActiveRoomRepository interface {
+	LeaveRoomRepository
+	LockUser(
+		ctx context.Context,
+		db Queryer,
+		userId entity.UserId,
+	) error
+	GetActiveRoomIdOfUser(
+		ctx context.Context,
+		db Queryer,
+		userId entity.UserId,
+	) (entity.RoomId, error)
+}
+
+// ユーザーが参加できるルームは同時に 1 つまで (roomId のルームは除いて確認する)
+//
+// autoLeave が true の場合は参加中のルームから退出させ、false の場合は entity.ErrAlreadyInRoom を返す
+// 同じユーザーの同時の作成・参加で両方が成功しないように、user の行をロックしてから確認する (トランザクション内で呼ぶ)
+func ensureNoActiveRoom(
+	ctx context.Context,
+	db QueryerAndExecer,
+	repo ActiveRoomRepository,
+	userId entity.UserId,
+	roomId entity.RoomId,
+	autoLeave bool,
+) error {
+	if err := repo.LockUser(ctx, db, userId); err != nil {
+		return err
+	}
+	activeRoomId, err := repo.GetActiveRoomIdOfUser(ctx, db, userId)
+	if err != nil {
+		return err
+	}
+	if activeRoomId == entity.RoomId(0) || activeRoomId == roomId {
+		return nil
+	}
+	if !autoLeave {
+		return &entity.ErrAlreadyInRoom{RoomId: activeRoomId}
+	}
+	if err := leaveRoom(ctx, db, repo, activeRoomId, userId); err != nil {
+		return fmt.Errorf("leaving room %d: %w", activeRoomId, err)
+	}
+	return nil
+}
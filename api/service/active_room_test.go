@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestEnsureNoActiveRoom(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err error
+		// 退出させるルーム
+		leaved entity.RoomId
+	}
+	tests := map[string]struct {
+		activeRoomId entity.RoomId
+		autoLeave    bool
+		want         want
+	}{
+		"ok_no_active_room": {
+			activeRoomId: 0,
+			want:         want{},
+		},
+		"ok_same_room": {
+			// 参加しようとしているルームは除いて確認する
+			activeRoomId: 10,
+			want:         want{},
+		},
+		"ok_auto_leave": {
+			activeRoomId: 20,
+			autoLeave:    true,
+			want:         want{leaved: 20},
+		},
+		"ng_already_in_room": {
+			activeRoomId: 20,
+			want:         want{err: &entity.ErrAlreadyInRoom{RoomId: 20}},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			db, _ := testutil.TxDB(t)
+			// user の行をロックしてから参加中のルームを確認する
+			var order []string
+			moq := &ActiveRoomRepositoryMock{}
+			moq.LockUserFunc = func(_ context.Context, _ Queryer, _ entity.UserId) error {
+				order = append(order, "LockUser")
+				return nil
+			}
+			moq.GetActiveRoomIdOfUserFunc = func(_ context.Context, _ Queryer, _ entity.UserId) (entity.RoomId, error) {
+				order = append(order, "GetActiveRoomIdOfUser")
+				return tt.activeRoomId, nil
+			}
+			moq.LeaveRoomFunc = func(_ context.Context, _ Execer, _ entity.RoomId, _ entity.UserId) error {
+				return nil
+			}
+			moq.CreateAuditLogFunc = func(_ context.Context, _ Execer, _ *entity.AuditLog) error {
+				return nil
+			}
+			moq.GetRoomUsersFunc = func(_ context.Context, _ Queryer, roomId entity.RoomId) ([]*entity.RoomUser, error) {
+				return []*entity.RoomUser{
+					{RoomId: roomId, UserId: 2, Status: entity.RoomUserStatusWaiting, Role: entity.RoomUserRolePlayer},
+				}, nil
+			}
+
+			err := ensureNoActiveRoom(context.Background(), db, moq, 1, 10, tt.autoLeave)
+			if tt.want.err != nil {
+				if err == nil || err.Error() != tt.want.err.Error() {
+					t.Fatalf("want error %v, but got %v", tt.want.err, err)
+				}
+				var errAlreadyInRoom *entity.ErrAlreadyInRoom
+				if !errors.As(err, &errAlreadyInRoom) || errAlreadyInRoom.RoomId != tt.activeRoomId {
+					t.Errorf("want ErrAlreadyInRoom with room %d, but got %v", tt.activeRoomId, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if d := cmp.Diff([]string{"LockUser", "GetActiveRoomIdOfUser"}, order); d != "" {
+				t.Errorf("calls differ (-want +got):\n%s", d)
+			}
+			calls := moq.LeaveRoomCalls()
+			if tt.want.leaved == 0 {
+				if len(calls) != 0 {
+					t.Errorf("want no leave, but got %+v", calls)
+				}
+				return
+			}
+			if len(calls) != 1 || calls[0].RoomId != tt.want.leaved || calls[0].UserId != 1 {
+				t.Errorf("want user 1 leaved room %d, but got %+v", tt.want.leaved, calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// 管理 API の操作を f と同じトランザクションで監査ログに記録する (f が失敗した場合は記録しない)
+func runAdminAction(
+	ctx context.Context,
+	db Beginner,
+	logger AuditLogger,
+	log *entity.AuditLog,
+	f func(tx *sqlx.Tx) error,
+) error {
+	// helper functions
+	fail := func(err error) error {
+		return err
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) error {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	if _, ok := GetAdminName(ctx); !ok {
+		return fail(errors.New("failed to get admin name from context"))
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	if err := f(tx); err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if err := recordAudit(ctx, tx, logger, log); err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+	return nil
+}
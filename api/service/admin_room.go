@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// TODO: convert to //go:generate when writing tests
+type AdminRoomRepository interface {
+	AuditLogger
+	GetRoom(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) (*entity.Room, error)
+	GetRoomUsers(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) ([]*entity.RoomUser, error)
+	LeaveAllRoomUsers(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+	) error
+	DissolveRoom(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+	) error
+	DeleteRoomChats(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+	) error
+	DeleteScore(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+		round int,
+		userId entity.UserId,
+	) error
+}
+
+type AdminRoom struct {
+	DB   Beginner
+	Repo AdminRoomRepository
+}
+
+type AdminRoomDetail struct {
+	Room *entity.Room
+	// 退出済みのメンバーを含む
+	RoomUsers []*entity.RoomUser
+}
+
+func (ar *AdminRoom) getRoom(ctx context.Context, tx *sqlx.Tx, roomId entity.RoomId) (*entity.Room, error) {
+	room, err := ar.Repo.GetRoom(ctx, tx, roomId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &entity.ErrNotFound{}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+func (ar *AdminRoom) GetRoomMembers(
+	ctx context.Context,
+	roomId entity.RoomId,
+) (*AdminRoomDetail, error) {
+	detail := &AdminRoomDetail{}
+	err := runAdminAction(ctx, ar.DB, ar.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionAdminGetRoomMembers,
+		TargetRoomId: roomId,
+	}, func(tx *sqlx.Tx) error {
+		room, err := ar.getRoom(ctx, tx, roomId)
+		if err != nil {
+			return err
+		}
+		roomUsers, err := ar.Repo.GetRoomUsers(ctx, tx, roomId)
+		if err != nil {
+			return err
+		}
+		detail.Room = room
+		detail.RoomUsers = roomUsers
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetRoomMembers: %w", err)
+	}
+	return detail, nil
+}
+
+// 状態に関わらずルームを解散し、全員を退出させる
+func (ar *AdminRoom) DissolveRoom(
+	ctx context.Context,
+	roomId entity.RoomId,
+) error {
+	err := runAdminAction(ctx, ar.DB, ar.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionAdminDissolveRoom,
+		TargetRoomId: roomId,
+	}, func(tx *sqlx.Tx) error {
+		if _, err := ar.getRoom(ctx, tx, roomId); err != nil {
+			return err
+		}
+		if err := ar.Repo.LeaveAllRoomUsers(ctx, tx, roomId); err != nil {
+			return err
+		}
+		if err := ar.Repo.DissolveRoom(ctx, tx, roomId); err != nil {
+			return err
+		}
+		return ar.Repo.DeleteRoomChats(ctx, tx, roomId)
+	})
+	if err != nil {
+		return fmt.Errorf("DissolveRoom: %w", err)
+	}
+	return nil
+}
+
+func (ar *AdminRoom) DeleteScore(
+	ctx context.Context,
+	roomId entity.RoomId,
+	round int,
+	userId entity.UserId,
+) error {
+	err := runAdminAction(ctx, ar.DB, ar.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionAdminDeleteScore,
+		TargetUserId: userId,
+		TargetRoomId: roomId,
+		Detail:       fmt.Sprintf("round=%d", round),
+	}, func(tx *sqlx.Tx) error {
+		err := ar.Repo.DeleteScore(ctx, tx, roomId, round, userId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return &entity.ErrNotFound{}
+		}
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteScore: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// TODO: convert to //go:generate when writing tests
+type AdminUserRepository interface {
+	AuditLogger
+	GetUserFromId(
+		ctx context.Context,
+		db Queryer,
+		userId entity.UserId,
+	) (*entity.User, error)
+	SearchUsersByName(
+		ctx context.Context,
+		db Queryer,
+		namePrefix string,
+		limit int,
+	) ([]*entity.User, error)
+	GetActiveRoomIdOfUser(
+		ctx context.Context,
+		db Queryer,
+		userId entity.UserId,
+	) (entity.RoomId, error)
+	RevokeAllUserSessions(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+	) error
+	IssueUserSession(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+	) (*entity.UserSession, error)
+	RotateUserToken(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+	) error
+	CreateUserBan(
+		ctx context.Context,
+		db Execer,
+		ban *entity.UserBan,
+	) error
+	GetActiveUserBan(
+		ctx context.Context,
+		db Queryer,
+		userId entity.UserId,
+		now time.Time,
+	) (*entity.UserBan, error)
+	LiftUserBans(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+	) error
+}
+
+type AdminUser struct {
+	DB      Beginner
+	Repo    AdminUserRepository
+	Clocker clock.Clocker
+	// nil の場合はキャッシュを使っていない
+	Cache SessionCacheInvalidator
+}
+
+type AdminUserDetail struct {
+	User *entity.User
+	// 参加中のルームが無い場合は 0
+	ActiveRoomId entity.RoomId
+	// 有効な BAN が無い場合は nil
+	Ban *entity.UserBan
+}
+
+func (au *AdminUser) getUser(ctx context.Context, tx *sqlx.Tx, userId entity.UserId) (*entity.User, error) {
+	user, err := au.Repo.GetUserFromId(ctx, tx, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &entity.ErrNotFound{}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (au *AdminUser) GetUser(
+	ctx context.Context,
+	userId entity.UserId,
+) (*AdminUserDetail, error) {
+	detail := &AdminUserDetail{}
+	err := runAdminAction(ctx, au.DB, au.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionAdminGetUser,
+		TargetUserId: userId,
+	}, func(tx *sqlx.Tx) error {
+		user, err := au.getUser(ctx, tx, userId)
+		if err != nil {
+			return err
+		}
+		activeRoomId, err := au.Repo.GetActiveRoomIdOfUser(ctx, tx, userId)
+		if err != nil {
+			return err
+		}
+		ban, err := au.Repo.GetActiveUserBan(ctx, tx, userId, au.Clocker.Now())
+		if err != nil {
+			return err
+		}
+		detail.User = user
+		detail.ActiveRoomId = activeRoomId
+		detail.Ban = ban
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetUser: %w", err)
+	}
+	return detail, nil
+}
+
+// 名前の前方一致で検索する
+func (au *AdminUser) SearchUsers(
+	ctx context.Context,
+	namePrefix string,
+	limit int,
+) ([]*entity.User, error) {
+	var users []*entity.User
+	err := runAdminAction(ctx, au.DB, au.Repo, &entity.AuditLog{
+		Action: entity.AuditActionAdminSearchUsers,
+		Detail: fmt.Sprintf("name_prefix=%q limit=%d", namePrefix, limit),
+	}, func(tx *sqlx.Tx) error {
+		var err error
+		users, err = au.Repo.SearchUsersByName(ctx, tx, namePrefix, limit)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SearchUsers: %w", err)
+	}
+	return users, nil
+}
+
+// 全てのセッションを無効化し、新しいトークンを発行する (運営からユーザーに伝える)
+//
+// 漏洩したトークンで再び認証できないように、平文で保存されている古いトークンも置き換える
+func (au *AdminUser) ResetUserToken(
+	ctx context.Context,
+	userId entity.UserId,
+) (*entity.UserSession, error) {
+	var session *entity.UserSession
+	err := runAdminAction(ctx, au.DB, au.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionAdminResetUserToken,
+		TargetUserId: userId,
+	}, func(tx *sqlx.Tx) error {
+		if _, err := au.getUser(ctx, tx, userId); err != nil {
+			return err
+		}
+		if err := au.Repo.RevokeAllUserSessions(ctx, tx, userId); err != nil {
+			return err
+		}
+		if err := au.Repo.RotateUserToken(ctx, tx, userId); err != nil {
+			return err
+		}
+		var err error
+		session, err = au.Repo.IssueUserSession(ctx, tx, userId)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ResetUserToken: %w", err)
+	}
+	if au.Cache != nil {
+		au.Cache.InvalidateUser(userId)
+	}
+	return session, nil
+}
+
+// 利用停止にする (duration が 0 の場合は無期限)
+//
+// 認証時のキャッシュを削除し、次のリクエストから拒否する
+func (au *AdminUser) BanUser(
+	ctx context.Context,
+	userId entity.UserId,
+	reason string,
+	duration time.Duration,
+) (*entity.UserBan, error) {
+	adminName, _ := GetAdminName(ctx)
+	now := au.Clocker.Now()
+	ban := &entity.UserBan{
+		UserId:    userId,
+		Reason:    reason,
+		StartsAt:  now,
+		AdminName: adminName,
+	}
+	if duration > 0 {
+		endsAt := now.Add(duration)
+		ban.EndsAt = &endsAt
+	}
+
+	err := runAdminAction(ctx, au.DB, au.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionAdminBanUser,
+		TargetUserId: userId,
+		Detail:       fmt.Sprintf("reason=%q duration=%s", reason, duration),
+	}, func(tx *sqlx.Tx) error {
+		if _, err := au.getUser(ctx, tx, userId); err != nil {
+			return err
+		}
+		return au.Repo.CreateUserBan(ctx, tx, ban)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("BanUser: %w", err)
+	}
+	if au.Cache != nil {
+		au.Cache.InvalidateUser(userId)
+	}
+	return ban, nil
+}
+
+// 有効な BAN を全て解除する
+func (au *AdminUser) UnbanUser(
+	ctx context.Context,
+	userId entity.UserId,
+) error {
+	err := runAdminAction(ctx, au.DB, au.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionAdminUnbanUser,
+		TargetUserId: userId,
+	}, func(tx *sqlx.Tx) error {
+		if _, err := au.getUser(ctx, tx, userId); err != nil {
+			return err
+		}
+		return au.Repo.LiftUserBans(ctx, tx, userId)
+	})
+	if err != nil {
+		return fmt.Errorf("UnbanUser: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+//go:generate go run github.com/matryer/moq -out audit_log_moq_test.go . AuditLogger
+type AuditLogger interface {
+	CreateAuditLog(
+		ctx context.Context,
+		db Execer,
+		log *entity.AuditLog,
+	) error
+}
+
+// 操作したユーザー・管理者とリクエスト Id を context から補って記録する
+//
+// 操作と同じトランザクションで記録し、記録に失敗した場合は操作も失敗させる
+func recordAudit(
+	ctx context.Context,
+	db Execer,
+	logger AuditLogger,
+	log *entity.AuditLog,
+) error {
+	if log.ActorUserId == 0 {
+		log.ActorUserId, _ = GetUserId(ctx)
+	}
+	log.ActorAdminName, _ = GetAdminName(ctx)
+	log.RequestId, _ = GetRequestId(ctx)
+	log.Detail = truncateRunes(log.Detail, config.AuditLogDetailMaxLength)
+	if err := logger.CreateAuditLog(ctx, db, log); err != nil {
+		return fmt.Errorf("recording audit log: %w", err)
+	}
+	return nil
+}
+
+// s が n 文字 (rune) を超える場合は n 文字に切り詰める
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	i := 0
+	for j := range s {
+		if i == n {
+			return s[:j]
+		}
+		i++
+	}
+	return s
+}
+
+// TODO: convert to //go:generate when writing tests
+type AuditLogRepository interface {
+	AuditLogger
+	GetAuditLogs(
+		ctx context.Context,
+		db Queryer,
+		filter *entity.AuditLogFilter,
+		limit int,
+	) ([]*entity.AuditLog, error)
+}
+
+type BeginnerAndQueryer interface {
+	Beginner
+	Queryer
+}
+
+type AuditLog struct {
+	DB   BeginnerAndQueryer
+	Repo AuditLogRepository
+}
+
+func (al *AuditLog) GetAuditLogs(
+	ctx context.Context,
+	filter *entity.AuditLogFilter,
+	limit int,
+) ([]*entity.AuditLog, error) {
+	var logs []*entity.AuditLog
+	err := runAdminAction(ctx, al.DB, al.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionAdminGetAuditLogs,
+		TargetUserId: filter.UserId,
+		TargetRoomId: filter.RoomId,
+		Detail:       fmt.Sprintf("action=%q since_id=%d limit=%d", filter.Action, filter.SinceId, limit),
+	}, func(tx *sqlx.Tx) error {
+		var err error
+		logs, err = al.Repo.GetAuditLogs(ctx, tx, filter, limit)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetAuditLogs: %w", err)
+	}
+	return logs, nil
+}
+
+// filter に一致する全てのログを id の昇順で f に渡す
+//
+// 件数が多い場合があるため、トランザクションを使わずに pageSize 件ずつ取得する
+func (al *AuditLog) ExportAuditLogs(
+	ctx context.Context,
+	filter *entity.AuditLogFilter,
+	pageSize int,
+	f func(log *entity.AuditLog) error,
+) error {
+	// エクスポートの開始を記録する
+	err := runAdminAction(ctx, al.DB, al.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionAdminExportAuditLogs,
+		TargetUserId: filter.UserId,
+		TargetRoomId: filter.RoomId,
+		Detail:       fmt.Sprintf("action=%q since_id=%d", filter.Action, filter.SinceId),
+	}, func(tx *sqlx.Tx) error {
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("ExportAuditLogs: %w", err)
+	}
+
+	page := *filter
+	for {
+		logs, err := al.Repo.GetAuditLogs(ctx, al.DB, &page, pageSize)
+		if err != nil {
+			return fmt.Errorf("ExportAuditLogs: %w", err)
+		}
+		for _, log := range logs {
+			if err := f(log); err != nil {
+				return fmt.Errorf("ExportAuditLogs: %w", err)
+			}
+		}
+		if len(logs) < pageSize {
+			return nil
+		}
+		page.SinceId = logs[len(logs)-1].Id
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+func TestRecordAudit(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ctx  func(ctx context.Context) context.Context
+		log  *entity.AuditLog
+		want *entity.AuditLog
+	}{
+		"ok_user": {
+			ctx: func(ctx context.Context) context.Context {
+				ctx = SetUserId(ctx, 1)
+				return SetRequestId(ctx, "req-1")
+			},
+			log: &entity.AuditLog{
+				Action:       entity.AuditActionRoomJoin,
+				TargetRoomId: 10,
+			},
+			want: &entity.AuditLog{
+				ActorUserId:  1,
+				Action:       entity.AuditActionRoomJoin,
+				TargetRoomId: 10,
+				RequestId:    "req-1",
+			},
+		},
+		"ok_explicit_actor": {
+			// `/user/create` は認証前なので context にユーザーが無い
+			ctx: func(ctx context.Context) context.Context {
+				return ctx
+			},
+			log: &entity.AuditLog{
+				Action:       entity.AuditActionUserCreate,
+				ActorUserId:  2,
+				TargetUserId: 2,
+			},
+			want: &entity.AuditLog{
+				Action:       entity.AuditActionUserCreate,
+				ActorUserId:  2,
+				TargetUserId: 2,
+			},
+		},
+		"ok_admin": {
+			ctx: func(ctx context.Context) context.Context {
+				ctx = SetAdminName(ctx, "alice")
+				return SetRequestId(ctx, "req-2")
+			},
+			log: &entity.AuditLog{
+				Action:       entity.AuditActionAdminDissolveRoom,
+				TargetRoomId: 10,
+			},
+			want: &entity.AuditLog{
+				ActorAdminName: "alice",
+				Action:         entity.AuditActionAdminDissolveRoom,
+				TargetRoomId:   10,
+				RequestId:      "req-2",
+			},
+		},
+		"ok_truncate_detail": {
+			// `audit_log`.`detail` のカラムの長さを超える分は切り捨てる
+			ctx: func(ctx context.Context) context.Context {
+				return SetUserId(ctx, 1)
+			},
+			log: &entity.AuditLog{
+				Action: entity.AuditActionRoomCreate,
+				Detail: strings.Repeat("あ", config.AuditLogDetailMaxLength+1),
+			},
+			want: &entity.AuditLog{
+				ActorUserId: 1,
+				Action:      entity.AuditActionRoomCreate,
+				Detail:      strings.Repeat("あ", config.AuditLogDetailMaxLength),
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			moq := &AuditLoggerMock{}
+			moq.CreateAuditLogFunc = func(_ context.Context, _ Execer, _ *entity.AuditLog) error {
+				return nil
+			}
+
+			ctx := tt.ctx(context.Background())
+			if err := recordAudit(ctx, nil, moq, tt.log); err != nil {
+				t.Fatal(err)
+			}
+
+			calls := moq.CreateAuditLogCalls()
+			if len(calls) != 1 {
+				t.Fatalf("CreateAuditLog must be called once (got %d)", len(calls))
+			}
+			if diff := cmp.Diff(tt.want, calls[0].Log); diff != "" {
+				t.Errorf("audit log is not match (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// TODO: convert to //go:generate when writing tests
+type BlockUserRepository interface {
+	GetUserFromId(
+		ctx context.Context,
+		db Queryer,
+		userId entity.UserId,
+	) (*entity.User, error)
+	CreateUserBlock(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+		blockedUserId entity.UserId,
+	) error
+	DeleteFriend(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+		friendUserId entity.UserId,
+	) error
+}
+
+type BlockUser struct {
+	DB   Beginner
+	Repo BlockUserRepository
+}
+
+// blockedUserId をブロックする
+//
+// フレンド関係 (申請中を含む) は解除する
+func (bu *BlockUser) BlockUser(
+	ctx context.Context,
+	userId entity.UserId,
+	blockedUserId entity.UserId,
+) error {
+	// helper functions
+	fail := func(err error) error {
+		return fmt.Errorf("BlockUser: %w", err)
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) error {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	if userId == blockedUserId {
+		return fail(fmt.Errorf("cannot block yourself"))
+	}
+
+	tx, err := bu.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	if _, err := bu.Repo.GetUserFromId(ctx, tx, blockedUserId); err != nil {
+		return failWithRollBack(tx, err)
+	}
+	if err := bu.Repo.CreateUserBlock(ctx, tx, userId, blockedUserId); err != nil {
+		return failWithRollBack(tx, err)
+	}
+	if err := bu.Repo.DeleteFriend(ctx, tx, userId, blockedUserId); err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+//go:generate go run github.com/matryer/moq -out change_room_live_moq_test.go . ChangeRoomLiveRepository
+type ChangeRoomLiveRepository interface {
+	GetRoom(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) (*entity.Room, error)
+	GetRoomUsers(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) ([]*entity.RoomUser, error)
+	UpdateRoomLiveId(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+		liveId entity.LiveId,
+	) error
+	UpdateRoomUserLiveDifficulty(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+		userId entity.UserId,
+		liveDifficulty entity.LiveDifficulty,
+	) error
+}
+
+type ChangeRoomLive struct {
+	DB   Beginner
+	Repo ChangeRoomLiveRepository
+}
+
+// Waiting 状態のルームの楽曲を変更する (host user のみ実行可能)
+//
+// - room.live_id, room.updated_at を更新する
+// - 難易度は前の楽曲に対して選択されたものなので、 host user 以外のメンバーは Normal に戻す
+func (cr *ChangeRoomLive) ChangeRoomLive(
+	ctx context.Context,
+	roomId entity.RoomId,
+	hostUserId entity.UserId,
+	liveId entity.LiveId,
+	hostLiveDifficulty entity.LiveDifficulty,
+) error {
+	// helper functions
+	fail := func(err error) error {
+		return err
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) error {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	tx, err := cr.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	room, err := cr.Repo.GetRoom(ctx, tx, roomId)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if room.HostUserId != hostUserId {
+		return failWithRollBack(tx, fmt.Errorf("hostUser mismatch: %v != %v", room.HostUserId, hostUserId))
+	}
+
+	if room.Status != entity.RoomStatusWaiting {
+		return failWithRollBack(tx, fmt.Errorf("room status is not waiting: %v", room.Status))
+	}
+
+	if err := cr.Repo.UpdateRoomLiveId(ctx, tx, roomId, liveId); err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	roomUsers, err := cr.Repo.GetRoomUsers(ctx, tx, roomId)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+	for _, roomUser := range roomUsers {
+		if roomUser.Status != entity.RoomUserStatusWaiting {
+			continue
+		}
+
+		liveDifficulty := entity.LiveDifficultyNormal
+		if roomUser.UserId == hostUserId {
+			liveDifficulty = hostLiveDifficulty
+		}
+		if roomUser.LiveDifficulty == liveDifficulty {
+			continue
+		}
+
+		if err := cr.Repo.UpdateRoomUserLiveDifficulty(ctx, tx, roomId, roomUser.UserId, liveDifficulty); err != nil {
+			return failWithRollBack(tx, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+
+	return nil
+}
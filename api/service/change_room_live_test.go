@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestChangeRoomLive(t *testing.T) {
+	t.Parallel()
+
+	type update struct {
+		UserId         entity.UserId
+		LiveDifficulty entity.LiveDifficulty
+	}
+	type want struct {
+		err bool
+		// 難易度を変更するメンバー
+		updates []update
+	}
+	tests := map[string]struct {
+		userId entity.UserId
+		status entity.RoomStatus
+		want   want
+	}{
+		"ok": {
+			// host user は指定した難易度、それ以外のメンバーは Normal に戻す (既に同じ難易度・退出済みのメンバーは更新しない)
+			userId: 1,
+			status: entity.RoomStatusWaiting,
+			want: want{
+				updates: []update{
+					{UserId: 1, LiveDifficulty: entity.LiveDifficultyHard},
+					{UserId: 2, LiveDifficulty: entity.LiveDifficultyNormal},
+				},
+			},
+		},
+		"ng_not_host": {
+			userId: 2,
+			status: entity.RoomStatusWaiting,
+			want:   want{err: true},
+		},
+		"ng_live_started": {
+			userId: 1,
+			status: entity.RoomStatusLiveStart,
+			want:   want{err: true},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			db, count := testutil.TxDB(t)
+			moq := &ChangeRoomLiveRepositoryMock{}
+			moq.GetRoomFunc = func(_ context.Context, _ Queryer, roomId entity.RoomId) (*entity.Room, error) {
+				return &entity.Room{
+					Id:         roomId,
+					LiveId:     1,
+					HostUserId: 1,
+					Status:     tt.status,
+				}, nil
+			}
+			moq.GetRoomUsersFunc = func(_ context.Context, _ Queryer, roomId entity.RoomId) ([]*entity.RoomUser, error) {
+				return []*entity.RoomUser{
+					{RoomId: roomId, UserId: 1, LiveDifficulty: entity.LiveDifficultyNormal, Status: entity.RoomUserStatusWaiting},
+					{RoomId: roomId, UserId: 2, LiveDifficulty: entity.LiveDifficultyHard, Status: entity.RoomUserStatusWaiting},
+					{RoomId: roomId, UserId: 3, LiveDifficulty: entity.LiveDifficultyNormal, Status: entity.RoomUserStatusWaiting},
+					{RoomId: roomId, UserId: 4, LiveDifficulty: entity.LiveDifficultyHard, Status: entity.RoomUserStatusLeaved},
+				}, nil
+			}
+			moq.UpdateRoomLiveIdFunc = func(_ context.Context, _ Execer, _ entity.RoomId, _ entity.LiveId) error {
+				return nil
+			}
+			moq.UpdateRoomUserLiveDifficultyFunc = func(_ context.Context, _ Execer, _ entity.RoomId, _ entity.UserId, _ entity.LiveDifficulty) error {
+				return nil
+			}
+
+			s := &ChangeRoomLive{DB: db, Repo: moq}
+			err := s.ChangeRoomLive(context.Background(), 10, tt.userId, 2, entity.LiveDifficultyHard)
+			if tt.want.err {
+				if err == nil {
+					t.Fatal("want error, but got nil")
+				}
+				// 楽曲・難易度は変更しない
+				if n := len(moq.UpdateRoomLiveIdCalls()) + len(moq.UpdateRoomUserLiveDifficultyCalls()); n != 0 {
+					t.Errorf("want no updates, but got %d", n)
+				}
+				if n := count.Rollbacks(); n != 1 {
+					t.Errorf("want 1 rollback, but got %d", n)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if calls := moq.UpdateRoomLiveIdCalls(); len(calls) != 1 || calls[0].LiveId != 2 {
+				t.Errorf("want live id updated to 2, but got %+v", calls)
+			}
+			var got []update
+			for _, c := range moq.UpdateRoomUserLiveDifficultyCalls() {
+				got = append(got, update{UserId: c.UserId, LiveDifficulty: c.LiveDifficulty})
+			}
+			if d := cmp.Diff(tt.want.updates, got); d != "" {
+				t.Errorf("updates differ (-want +got):\n%s", d)
+			}
+			if n := count.Commits(); n != 1 {
+				t.Errorf("want 1 commit, but got %d", n)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 )
 
 type userIDKey struct{}
+type sessionIDKey struct{}
 
 func SetUserId(ctx context.Context, uid entity.UserId) context.Context {
 	return context.WithValue(ctx, userIDKey{}, uid)
@@ -16,3 +17,37 @@ func GetUserId(ctx context.Context) (entity.UserId, bool) {
 	id, ok := ctx.Value(userIDKey{}).(entity.UserId)
 	return id, ok
 }
+
+// 認証に使われたセッション
+func SetSessionId(ctx context.Context, sid entity.UserSessionId) context.Context {
+	return context.WithValue(ctx, sessionIDKey{}, sid)
+}
+
+func GetSessionId(ctx context.Context) (entity.UserSessionId, bool) {
+	id, ok := ctx.Value(sessionIDKey{}).(entity.UserSessionId)
+	return id, ok
+}
+
+type adminNameKey struct{}
+
+// 管理 API の認証に使われた ADMIN_CREDENTIALS の名前
+func SetAdminName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, adminNameKey{}, name)
+}
+
+func GetAdminName(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(adminNameKey{}).(string)
+	return name, ok
+}
+
+type requestIDKey struct{}
+
+// 監査ログに記録するリクエスト Id (X-Request-Id)
+func SetRequestId(ctx context.Context, rid string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, rid)
+}
+
+func GetRequestId(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
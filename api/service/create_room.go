@@ -11,6 +11,7 @@ import (
 // TODO: convert to //go:generate when writing tests
 // go:generate go run github.com/matryer/moq -out create_room_moq_test.go . CreateRoomRepository
 type CreateRoomRepository interface {
+	ActiveRoomRepository
 	CreateRoom(
 		ctx context.Context,
 		db Execer,
@@ -24,16 +25,26 @@ type CreateRoomRepository interface {
 		userId entity.UserId,
 		liveDifficulty entity.LiveDifficulty,
 	) (*entity.RoomUser, error)
+	CreateRoomSetlist(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+		liveIds []entity.LiveId,
+	) ([]*entity.RoomSetlistItem, error)
 }
 
 type CreateRoom struct {
 	DB   Beginner
 	Repo CreateRoomRepository
+	// 既に別のルームに参加している場合に自動で退出させる (false の場合は entity.ErrAlreadyInRoom を返す)
+	AutoLeaveActiveRoom bool
 }
 
+// setlist には 2 ラウンド目以降に演奏する楽曲を指定する (空の場合は liveId のみ)
 func (cr *CreateRoom) CreateRoom(
 	ctx context.Context,
 	liveId entity.LiveId,
+	setlist []entity.LiveId,
 	hostUserId entity.UserId,
 ) (*entity.Room, *entity.RoomUser, error) {
 	// helper functions
@@ -49,16 +60,35 @@ func (cr *CreateRoom) CreateRoom(
 		return nil, nil, fmt.Errorf("BeginTxx: %w", err)
 	}
 
+	if err := ensureNoActiveRoom(ctx, tx, cr.Repo, hostUserId, entity.RoomId(0), cr.AutoLeaveActiveRoom); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("CreateRoom: %w", err))
+	}
+
 	room, err := cr.Repo.CreateRoom(ctx, tx, liveId, hostUserId)
 	if err != nil {
 		return failWithRollBack(tx, fmt.Errorf("CreateRoom: %w", err))
 	}
 
+	if len(setlist) > 0 {
+		if _, err := cr.Repo.CreateRoomSetlist(ctx, tx, room.Id, append([]entity.LiveId{liveId}, setlist...)); err != nil {
+			return failWithRollBack(tx, fmt.Errorf("CreateRoomSetlist: %w", err))
+		}
+	}
+
 	roomUser, err := cr.Repo.CreateRoomUser(ctx, tx, room.Id, hostUserId, entity.LiveDifficultyNormal)
 	if err != nil {
 		return failWithRollBack(tx, fmt.Errorf("CreateRoomUser: %w", err))
 	}
 
+	if err := recordAudit(ctx, tx, cr.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionRoomCreate,
+		ActorUserId:  hostUserId,
+		TargetRoomId: room.Id,
+		Detail:       fmt.Sprintf("live_id=%d setlist=%v", liveId, setlist),
+	}); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("CreateRoom: %w", err))
+	}
+
 	if err := tx.Commit(); err != nil {
 		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
 	}
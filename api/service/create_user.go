@@ -4,32 +4,72 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/pollenjp/gameserver-go/api/entity"
 )
 
 // TODO: convert to //go:generate when writing tests
 // go:generate go run github.com/matryer/moq -out create_user_moq_test.go . CreateUserRepository
 type CreateUserRepository interface {
+	AuditLogger
 	CreateUser(ctx context.Context, db Execer, u *entity.User) error
+	CreateUserSession(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+		token entity.UserTokenType,
+	) (*entity.UserSession, error)
 }
 
 type CreateUser struct {
-	DB   Execer
+	DB   Beginner
 	Repo CreateUserRepository
 }
 
+// ユーザーの作成と同時に u.Token を認証トークンとするセッションを作成する
 func (ru *CreateUser) CreateUser(
 	ctx context.Context,
 	name string,
 	leaderCard entity.LeaderCardIdIDType,
 ) (*entity.User, error) {
+	// helper functions
+	fail := func(err error) (*entity.User, error) {
+		return nil, fmt.Errorf("CreateUser: %w", err)
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) (*entity.User, error) {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
 	u := &entity.User{
 		Name:         name,
 		LeaderCardId: leaderCard,
 	}
 
-	if err := ru.Repo.CreateUser(ctx, ru.DB, u); err != nil {
-		return nil, fmt.Errorf("CreateUser: %w", err)
+	tx, err := ru.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	if err := ru.Repo.CreateUser(ctx, tx, u); err != nil {
+		return failWithRollBack(tx, err)
+	}
+	if _, err := ru.Repo.CreateUserSession(ctx, tx, u.Id, u.Token); err != nil {
+		return failWithRollBack(tx, err)
+	}
+	if err := recordAudit(ctx, tx, ru.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionUserCreate,
+		ActorUserId:  u.Id,
+		TargetUserId: u.Id,
+		Detail:       fmt.Sprintf("name=%q leader_card_id=%d", u.Name, u.LeaderCardId),
+	}); err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
 	}
 	return u, nil
 }
@@ -3,14 +3,47 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/config"
 	"github.com/pollenjp/gameserver-go/api/entity"
 )
 
+// ライブ中のルームで `/room/end` を受け付ける期限 (ライブ中でない場合は false)
+func resultDeadline(room *entity.Room) (time.Time, bool) {
+	if room.Status != entity.RoomStatusLiveStart || room.StartAt == nil {
+		return time.Time{}, false
+	}
+	return room.StartAt.Add(config.ResultDeadline), true
+}
+
+// ライブ中のルームで `/room/end` の期限を過ぎたか (過ぎた場合は未送信のメンバーを待たない)
+func isResultDeadlinePassed(room *entity.Room, now time.Time) bool {
+	deadline, ok := resultDeadline(room)
+	return ok && now.After(deadline)
+}
+
 // TODO: convert to //go:generate when writing tests
 // go:generate go run github.com/matryer/moq -out end_room_list_moq_test.go . EndRoomRepository
 type EndRoomRepository interface {
+	AuditLogger
+
+	// `/room/next_round` と同時に実行された場合に古いラウンドのスコアとして格納しないように room の行をロックする
+	GetRoomForUpdate(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) (*entity.Room, error)
+
+	GetRoomUser(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+		userId entity.UserId,
+	) (*entity.RoomUser, error)
+
 	CreateScore(
 		ctx context.Context,
 		db Execer,
@@ -27,12 +60,15 @@ type EndRoomRepository interface {
 }
 
 type EndRoom struct {
-	DB   Beginner
-	Repo EndRoomRepository
+	DB      Beginner
+	Repo    EndRoomRepository
+	Clocker clock.Clocker
 }
 
-// - Score の格納
+// - Score の格納 (現在のラウンドのスコアとして格納する)
 // - RoomUser の状態を変更する end など
+//
+// 再接続したクライアントからの送信も config.ResultDeadline までは受け付ける
 func (er *EndRoom) EndRoom(
 	ctx context.Context,
 	score *entity.Score,
@@ -53,6 +89,37 @@ func (er *EndRoom) EndRoom(
 		return fail(fmt.Errorf("BeginTxx: %w", err))
 	}
 
+	room, err := er.Repo.GetRoomForUpdate(ctx, tx, score.RoomId)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+	score.Round = room.Round
+
+	deadline, ok := resultDeadline(room)
+	if !ok {
+		return failWithRollBack(tx, fmt.Errorf("room is not in live: %v", room.Status))
+	}
+	if er.Clocker.Now().After(deadline) {
+		return failWithRollBack(tx, fmt.Errorf("result deadline has passed: %v", deadline))
+	}
+
+	roomUser, err := er.Repo.GetRoomUser(ctx, tx, score.RoomId, score.UserId)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+	// 観戦者はスコアを持たない
+	if roomUser.IsSpectator() {
+		return failWithRollBack(tx, &entity.ErrPermissionDenied{})
+	}
+	switch roomUser.Status {
+	case entity.RoomUserStatusWaiting:
+		// do nothing
+	case entity.RoomUserStatusFinished:
+		return failWithRollBack(tx, fmt.Errorf("result is already submitted"))
+	default:
+		return failWithRollBack(tx, &entity.ErrPermissionDenied{})
+	}
+
 	if err := er.Repo.UpdateRoomUserStatus(ctx, tx, score.RoomId, score.UserId, entity.RoomUserStatusFinished); err != nil {
 		// TODO: error が起きた場合でも Rollback せずに Status は End にしたほうが良いのか？
 		return failWithRollBack(tx, err)
@@ -61,6 +128,18 @@ func (er *EndRoom) EndRoom(
 	if err := er.Repo.CreateScore(ctx, tx, score); err != nil {
 		return failWithRollBack(tx, err)
 	}
+	if err := recordAudit(ctx, tx, er.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionRoomEnd,
+		ActorUserId:  score.UserId,
+		TargetRoomId: score.RoomId,
+		Detail: fmt.Sprintf(
+			"round=%d score=%d judge=[%d %d %d %d %d]",
+			score.Round, score.Score,
+			score.JudgePerfect, score.JudgeGreat, score.JudgeGood, score.JudgeBad, score.JudgeMiss,
+		),
+	}); err != nil {
+		return failWithRollBack(tx, err)
+	}
 
 	if err := tx.Commit(); err != nil {
 		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
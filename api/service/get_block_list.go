@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// Repository からの受け取り
+type BlockListItem struct {
+	UserId    entity.UserId `db:"user_id"`
+	Name      string        `db:"name"`
+	BlockedAt time.Time     `db:"blocked_at"`
+}
+
+// TODO: convert to //go:generate when writing tests
+type GetBlockListRepository interface {
+	GetBlockList(
+		ctx context.Context,
+		db Queryer,
+		userId entity.UserId,
+	) ([]*BlockListItem, error)
+}
+
+type GetBlockList struct {
+	DB   Queryer
+	Repo GetBlockListRepository
+}
+
+func (gb *GetBlockList) GetBlockList(
+	ctx context.Context,
+	userId entity.UserId,
+) ([]*BlockListItem, error) {
+	blockList, err := gb.Repo.GetBlockList(ctx, gb.DB, userId)
+	if err != nil {
+		return nil, err
+	}
+	return blockList, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// handler への返り値に利用 (参加中のルームが無い場合は Room と RoomUser が nil)
+type CurrentRoomResult struct {
+	Room     *entity.Room
+	RoomUser *entity.RoomUser
+	// ライブ中の場合のみ設定される
+	ResultDeadline *time.Time
+}
+
+// TODO: convert to //go:generate when writing tests
+type GetCurrentRoomRepository interface {
+	GetActiveRoomIdOfUser(
+		ctx context.Context,
+		db Queryer,
+		userId entity.UserId,
+	) (entity.RoomId, error)
+	GetRoom(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) (*entity.Room, error)
+	GetRoomUser(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+		userId entity.UserId,
+	) (*entity.RoomUser, error)
+}
+
+type GetCurrentRoom struct {
+	DB   Queryer
+	Repo GetCurrentRoomRepository
+}
+
+// 参加中のルーム (Waiting / LiveStart) の Id を返す (参加していない場合は 0)
+func (gc *GetCurrentRoom) GetCurrentRoomId(
+	ctx context.Context,
+	userId entity.UserId,
+) (entity.RoomId, error) {
+	roomId, err := gc.Repo.GetActiveRoomIdOfUser(ctx, gc.DB, userId)
+	if err != nil {
+		return entity.RoomId(0), err
+	}
+	return roomId, nil
+}
+
+// 再接続したクライアントが参加中のルームに復帰するための情報を返す
+func (gc *GetCurrentRoom) GetCurrentRoom(
+	ctx context.Context,
+	userId entity.UserId,
+) (*CurrentRoomResult, error) {
+	db := gc.DB
+
+	roomId, err := gc.Repo.GetActiveRoomIdOfUser(ctx, db, userId)
+	if err != nil {
+		return nil, err
+	}
+	if roomId == entity.RoomId(0) {
+		return &CurrentRoomResult{}, nil
+	}
+
+	room, err := gc.Repo.GetRoom(ctx, db, roomId)
+	if err != nil {
+		return nil, err
+	}
+	roomUser, err := gc.Repo.GetRoomUser(ctx, db, roomId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CurrentRoomResult{
+		Room:     room,
+		RoomUser: roomUser,
+	}
+	if deadline, ok := resultDeadline(room); ok {
+		result.ResultDeadline = &deadline
+	}
+	return result, nil
+}
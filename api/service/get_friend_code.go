@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// TODO: convert to //go:generate when writing tests
+type GetFriendCodeRepository interface {
+	GetUserFromId(ctx context.Context, db Queryer, userId entity.UserId) (*entity.User, error)
+	IssueUserFriendCode(ctx context.Context, db Execer, userId entity.UserId) error
+}
+
+type GetFriendCode struct {
+	DB   QueryerAndExecer
+	Repo GetFriendCodeRepository
+}
+
+// フレンドコードを返す (フレンドコード導入前に作成されたユーザーにはここで発行する)
+func (gf *GetFriendCode) GetFriendCode(
+	ctx context.Context,
+	userId entity.UserId,
+) (entity.FriendCodeType, error) {
+	// helper functions
+	fail := func(err error) (entity.FriendCodeType, error) {
+		return "", fmt.Errorf("GetFriendCode: %w", err)
+	}
+
+	db := gf.DB
+
+	u, err := gf.Repo.GetUserFromId(ctx, db, userId)
+	if err != nil {
+		return fail(err)
+	}
+	if u.FriendCode != "" {
+		return u.FriendCode, nil
+	}
+
+	if err := gf.Repo.IssueUserFriendCode(ctx, db, userId); err != nil {
+		return fail(err)
+	}
+
+	u, err = gf.Repo.GetUserFromId(ctx, db, userId)
+	if err != nil {
+		return fail(err)
+	}
+	return u.FriendCode, nil
+}
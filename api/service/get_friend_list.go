@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// Repository からの受け取り
+type FriendListItem struct {
+	UserId       entity.UserId             `db:"user_id"`
+	Name         string                    `db:"name"`
+	LeaderCardId entity.LeaderCardIdIDType `db:"leader_card_id"`
+	Relation     entity.FriendRelation     `db:"relation"`
+}
+
+// TODO: convert to //go:generate when writing tests
+type GetFriendListRepository interface {
+	GetFriendList(
+		ctx context.Context,
+		db Queryer,
+		userId entity.UserId,
+	) ([]*FriendListItem, error)
+}
+
+type GetFriendList struct {
+	DB   Queryer
+	Repo GetFriendListRepository
+}
+
+func (gf *GetFriendList) GetFriendList(
+	ctx context.Context,
+	userId entity.UserId,
+) ([]*FriendListItem, error) {
+	friendList, err := gf.Repo.GetFriendList(ctx, gf.DB, userId)
+	if err != nil {
+		return nil, err
+	}
+	return friendList, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// handler への返り値に利用.
+type LiveProgressResult struct {
+	Round        int
+	ProgressList []*entity.LiveProgress
+}
+
+// TODO: convert to //go:generate when writing tests
+type GetLiveProgressRepository interface {
+	GetRoom(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) (*entity.Room, error)
+	GetRoomUsers(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) ([]*entity.RoomUser, error)
+}
+
+type GetLiveProgress struct {
+	DB    Queryer
+	Repo  GetLiveProgressRepository
+	Store LiveProgressStore
+}
+
+// 現在のラウンドの全員の途中経過を返す (観戦者を含むルームのメンバーのみ取得可能)
+func (gp *GetLiveProgress) GetLiveProgress(
+	ctx context.Context,
+	roomId entity.RoomId,
+	userId entity.UserId,
+) (*LiveProgressResult, error) {
+	// helper functions
+	fail := func(err error) (*LiveProgressResult, error) {
+		return nil, fmt.Errorf("GetLiveProgress: %w", err)
+	}
+
+	db := gp.DB
+
+	room, err := gp.Repo.GetRoom(ctx, db, roomId)
+	if err != nil {
+		return fail(err)
+	}
+
+	roomUsers, err := gp.Repo.GetRoomUsers(ctx, db, roomId)
+	if err != nil {
+		return fail(err)
+	}
+
+	isMember := false
+	for _, roomUser := range roomUsers {
+		if roomUser.UserId == userId && roomUser.Status != entity.RoomUserStatusLeaved {
+			isMember = true
+			break
+		}
+	}
+	if !isMember {
+		return fail(&entity.ErrPermissionDenied{})
+	}
+
+	return &LiveProgressResult{
+		Round:        room.Round,
+		ProgressList: gp.Store.GetProgressList(roomId, room.Round),
+	}, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+//go:generate go run github.com/matryer/moq -out get_room_chats_moq_test.go . GetRoomChatsRepository
+type GetRoomChatsRepository interface {
+	GetRoomUser(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+		userId entity.UserId,
+	) (*entity.RoomUser, error)
+	GetRoomChats(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+		viewerUserId entity.UserId,
+		sinceId entity.RoomChatId,
+		limit int,
+	) ([]*entity.RoomChat, error)
+}
+
+type GetRoomChats struct {
+	DB   Queryer
+	Repo GetRoomChatsRepository
+}
+
+// sinceId より後のチャットを古い順に返す (ルームのメンバーのみ取得可能)
+// 自分がブロックしているユーザーのチャットは含まない
+//
+// 返したチャットの最後の Id を次の sinceId として利用する
+func (gc *GetRoomChats) GetRoomChats(
+	ctx context.Context,
+	roomId entity.RoomId,
+	userId entity.UserId,
+	sinceId entity.RoomChatId,
+) ([]*entity.RoomChat, error) {
+	// helper functions
+	fail := func(err error) ([]*entity.RoomChat, error) {
+		return nil, fmt.Errorf("GetRoomChats: %w", err)
+	}
+
+	db := gc.DB
+
+	roomUser, err := gc.Repo.GetRoomUser(ctx, db, roomId, userId)
+	if err != nil {
+		return fail(err)
+	}
+	if roomUser.Status == entity.RoomUserStatusLeaved {
+		return fail(&entity.ErrPermissionDenied{})
+	}
+
+	chats, err := gc.Repo.GetRoomChats(ctx, db, roomId, userId, sinceId, config.ChatFetchLimit)
+	if err != nil {
+		return fail(err)
+	}
+	return chats, nil
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestGetRoomChats(t *testing.T) {
+	t.Parallel()
+
+	chats := []*entity.RoomChat{
+		{Id: 21, RoomId: 10, UserId: 2, Message: "hello"},
+		{Id: 22, RoomId: 10, UserId: 3, Message: "hi"},
+	}
+
+	type want struct {
+		chats []*entity.RoomChat
+		err   error
+	}
+	tests := map[string]struct {
+		status  entity.RoomUserStatus
+		sinceId entity.RoomChatId
+		want    want
+	}{
+		"ok": {
+			status: entity.RoomUserStatusWaiting,
+			want:   want{chats: chats},
+		},
+		"ok_since": {
+			// 前回取得した最後の Id より後のチャットを取得する
+			status:  entity.RoomUserStatusFinished,
+			sinceId: 20,
+			want:    want{chats: chats},
+		},
+		"ng_leaved": {
+			status:  entity.RoomUserStatusLeaved,
+			sinceId: 20,
+			want:    want{err: &entity.ErrPermissionDenied{}},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			db, _ := testutil.TxDB(t)
+			moq := &GetRoomChatsRepositoryMock{}
+			moq.GetRoomUserFunc = func(_ context.Context, _ Queryer, roomId entity.RoomId, userId entity.UserId) (*entity.RoomUser, error) {
+				return &entity.RoomUser{RoomId: roomId, UserId: userId, Status: tt.status}, nil
+			}
+			moq.GetRoomChatsFunc = func(_ context.Context, _ Queryer, _ entity.RoomId, _ entity.UserId, _ entity.RoomChatId, _ int) ([]*entity.RoomChat, error) {
+				return chats, nil
+			}
+
+			s := &GetRoomChats{DB: db, Repo: moq}
+			got, err := s.GetRoomChats(context.Background(), 10, 1, tt.sinceId)
+			if tt.want.err != nil {
+				if err == nil || errors.Unwrap(err).Error() != tt.want.err.Error() {
+					t.Fatalf("want error %v, but got %v", tt.want.err, err)
+				}
+				if n := len(moq.GetRoomChatsCalls()); n != 0 {
+					t.Errorf("want no chats fetched, but got %d", n)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tt.want.chats, got); d != "" {
+				t.Errorf("chats differ (-want +got):\n%s", d)
+			}
+
+			// sinceId と取得件数の上限をそのまま渡す
+			calls := moq.GetRoomChatsCalls()
+			if len(calls) != 1 {
+				t.Fatalf("want 1 call, but got %d", len(calls))
+			}
+			if calls[0].ViewerUserId != 1 || calls[0].SinceId != tt.sinceId || calls[0].Limit != config.ChatFetchLimit {
+				t.Errorf("want viewer 1, since %d, limit %d, but got %+v", tt.sinceId, config.ChatFetchLimit, calls[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// Repository からの受け取り
+type RoomInvitationItem struct {
+	InvitationId  entity.RoomInvitationId `db:"invitation_id"`
+	RoomId        entity.RoomId           `db:"room_id"`
+	LiveId        entity.LiveId           `db:"live_id"`
+	InviterUserId entity.UserId           `db:"inviter_user_id"`
+	InviterName   string                  `db:"inviter_name"`
+	ExpiresAt     time.Time               `db:"expires_at"`
+}
+
+// TODO: convert to //go:generate when writing tests
+type GetRoomInvitationsRepository interface {
+	GetReceivedRoomInvitations(
+		ctx context.Context,
+		db Queryer,
+		inviteeUserId entity.UserId,
+		now time.Time,
+	) ([]*RoomInvitationItem, error)
+}
+
+type GetRoomInvitations struct {
+	DB      Queryer
+	Repo    GetRoomInvitationsRepository
+	Clocker clock.Clocker
+}
+
+// 受け取った招待の一覧 (期限切れ・参加できなくなったルームへの招待は除く)
+func (gi *GetRoomInvitations) GetRoomInvitations(
+	ctx context.Context,
+	userId entity.UserId,
+) ([]*RoomInvitationItem, error) {
+	invitations, err := gi.Repo.GetReceivedRoomInvitations(ctx, gi.DB, userId, gi.Clocker.Now())
+	if err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
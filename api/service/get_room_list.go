@@ -19,12 +19,21 @@ type GetRoomListRepository interface {
 		ctx context.Context,
 		db Queryer,
 		RoomStatus entity.RoomStatus,
+		viewerUserId entity.UserId,
 	) ([]*RoomInfoItem, error)
 	GetRoomListFilteredByLiveId(
 		ctx context.Context,
 		db Queryer,
 		RoomStatus entity.RoomStatus,
 		liveId entity.LiveId,
+		viewerUserId entity.UserId,
+	) ([]*RoomInfoItem, error)
+	GetRoomListOfFriends(
+		ctx context.Context,
+		db Queryer,
+		RoomStatus entity.RoomStatus,
+		userId entity.UserId,
+		liveId entity.LiveId,
 	) ([]*RoomInfoItem, error)
 }
 
@@ -33,17 +42,27 @@ type GetRoomList struct {
 	Repo GetRoomListRepository
 }
 
+// friendOnly が true の場合はフレンドが host または参加しているルームのみを返す (要認証)
+//
+// 認証済みの場合はブロック関係にあるユーザーがいるルームを除く
 func (ru *GetRoomList) GetRoomList(
 	ctx context.Context,
 	liveId entity.LiveId,
+	friendOnly bool,
 ) ([]*RoomInfoItem, error) {
 	var roomItemList []*RoomInfoItem
 	{
 		var err error
-		if liveId == entity.LiveId(0) {
-			roomItemList, err = ru.Repo.GetRoomList(ctx, ru.DB, entity.RoomStatusWaiting)
+		userId, ok := GetUserId(ctx)
+		if friendOnly {
+			if !ok {
+				return nil, &entity.ErrUnauthorized{}
+			}
+			roomItemList, err = ru.Repo.GetRoomListOfFriends(ctx, ru.DB, entity.RoomStatusWaiting, userId, liveId)
+		} else if liveId == entity.LiveId(0) {
+			roomItemList, err = ru.Repo.GetRoomList(ctx, ru.DB, entity.RoomStatusWaiting, userId)
 		} else {
-			roomItemList, err = ru.Repo.GetRoomListFilteredByLiveId(ctx, ru.DB, entity.RoomStatusWaiting, liveId)
+			roomItemList, err = ru.Repo.GetRoomListFilteredByLiveId(ctx, ru.DB, entity.RoomStatusWaiting, liveId, userId)
 		}
 		if err != nil {
 			return nil, err
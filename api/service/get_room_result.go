@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log"
 
+	"github.com/pollenjp/gameserver-go/api/clock"
 	"github.com/pollenjp/gameserver-go/api/entity"
 )
 
@@ -53,7 +55,12 @@ type RoomUserAndScore struct {
 // TODO: convert to //go:generate when writing tests
 // go:generate go run github.com/matryer/moq -out get_room_result_moq_test.go . GetRoomResultRepository
 type GetRoomResultRepository interface {
-	GetRoomUserAndScoreInRoom(ctx context.Context, db Queryer, roomId entity.RoomId) ([]*RoomUserAndScore, error)
+	GetRoom(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) (*entity.Room, error)
+	GetRoomUserAndScoreInRoom(ctx context.Context, db Queryer, roomId entity.RoomId, round int) ([]*RoomUserAndScore, error)
 	GetRoomUsers(
 		ctx context.Context,
 		db Queryer,
@@ -62,15 +69,20 @@ type GetRoomResultRepository interface {
 }
 
 type GetRoomResult struct {
-	DB   Queryer
-	Repo GetRoomResultRepository
+	DB      Queryer
+	Repo    GetRoomResultRepository
+	Clocker clock.Clocker
 }
 
 type RoomUserResultList []*RoomUserResult
 
+// round に 0 を指定した場合は現在のラウンドの結果を返す
+//
+// config.ResultDeadline を過ぎた場合は未送信のメンバーを待たずに送信済みの結果を返す
 func (grr *GetRoomResult) GetRoomResult(
 	ctx context.Context,
 	roomId entity.RoomId,
+	round int,
 ) (RoomUserResultList, error) {
 	// TODO: roomId auth check
 	// ルームに参加していないユーザーは結果を見れない
@@ -83,25 +95,43 @@ func (grr *GetRoomResult) GetRoomResult(
 	// 	m[ru.UserId] = ru
 	// }
 
-	roomUsers, err := grr.Repo.GetRoomUsers(ctx, grr.DB, roomId)
+	room, err := grr.Repo.GetRoom(ctx, grr.DB, roomId)
 	if err != nil {
 		return nil, err
 	}
-
-	Status2RoomUser := make(map[entity.RoomUserStatus]*entity.RoomUser)
-	UserId2RoomUser := make(map[entity.UserId]*entity.RoomUser)
-	for _, ru := range roomUsers {
-		Status2RoomUser[ru.Status] = ru
-		UserId2RoomUser[ru.UserId] = ru
+	if round == 0 {
+		round = room.Round
 	}
+	if round > room.Round {
+		return nil, fmt.Errorf("round %d has not started yet (current: %d)", round, room.Round)
+	}
+
+	// 現在のラウンドは全員のスコアが揃うまで結果を返さない (config.ResultDeadline を過ぎた場合は送信済みのスコアのみ返す)
+	// 過去のラウンドも期限を過ぎて進めた場合は未送信のメンバーのスコアを含まない
+	if round == room.Round {
+		roomUsers, err := grr.Repo.GetRoomUsers(ctx, grr.DB, roomId)
+		if err != nil {
+			return nil, err
+		}
+
+		Status2RoomUser := make(map[entity.RoomUserStatus]*entity.RoomUser)
+		UserId2RoomUser := make(map[entity.UserId]*entity.RoomUser)
+		for _, ru := range roomUsers {
+			if ru.IsSpectator() {
+				continue
+			}
+			Status2RoomUser[ru.Status] = ru
+			UserId2RoomUser[ru.UserId] = ru
+		}
 
-	// もし WaitingUser の人がいる場合は結果を見れない
-	if _, ok := Status2RoomUser[entity.RoomUserStatusWaiting]; ok {
-		log.Printf("GetRoomResult: waiting user exists")
-		return RoomUserResultList{}, nil
+		// もし WaitingUser の人がいる場合は結果を見れない
+		if _, ok := Status2RoomUser[entity.RoomUserStatusWaiting]; ok && !isResultDeadlinePassed(room, grr.Clocker.Now()) {
+			log.Printf("GetRoomResult: waiting user exists")
+			return RoomUserResultList{}, nil
+		}
 	}
 
-	userAndScores, err := grr.Repo.GetRoomUserAndScoreInRoom(ctx, grr.DB, roomId)
+	userAndScores, err := grr.Repo.GetRoomUserAndScoreInRoom(ctx, grr.DB, roomId, round)
 	if err != nil {
 		return nil, err
 	}
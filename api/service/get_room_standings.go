@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// 全ラウンドを通した累計成績
+type RoomStanding struct {
+	UserId       entity.UserId `db:"user_id"`
+	TotalScore   int           `db:"total_score"`
+	PlayedRounds int           `db:"played_rounds"`
+}
+
+// TODO: convert to //go:generate when writing tests
+type GetRoomStandingsRepository interface {
+	GetRoomStandings(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) ([]*RoomStanding, error)
+}
+
+type GetRoomStandings struct {
+	DB   Queryer
+	Repo GetRoomStandingsRepository
+}
+
+// TotalScore の降順で返す
+func (grs *GetRoomStandings) GetRoomStandings(
+	ctx context.Context,
+	roomId entity.RoomId,
+) ([]*RoomStanding, error) {
+	standings, err := grs.Repo.GetRoomStandings(ctx, grs.DB, roomId)
+	if err != nil {
+		return nil, err
+	}
+	return standings, nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+//go:generate go run github.com/matryer/moq -out idempotency_moq_test.go . IdempotencyRepository
+type IdempotencyRepository interface {
+	CreateIdempotencyRecord(
+		ctx context.Context,
+		db Execer,
+		record *entity.IdempotencyRecord,
+	) error
+	GetIdempotencyRecord(
+		ctx context.Context,
+		db Queryer,
+		userId entity.UserId,
+		key entity.IdempotencyKeyType,
+	) (*entity.IdempotencyRecord, error)
+	RenewIdempotencyRecord(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+		key entity.IdempotencyKeyType,
+		lockedUntil time.Time,
+	) error
+	CompleteIdempotencyRecord(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+		key entity.IdempotencyKeyType,
+		statusCode int,
+		responseBody []byte,
+	) error
+	DeleteIdempotencyRecord(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+		key entity.IdempotencyKeyType,
+	) error
+	DeleteExpiredIdempotencyRecords(
+		ctx context.Context,
+		db Execer,
+		now time.Time,
+		limit int,
+	) (int64, error)
+}
+
+// Idempotency-Key 毎に最初のレスポンスを保存する
+//
+// 処理の前に Begin でキーを確保し、処理の後に Complete (保存しない場合は Abort) を呼ぶ
+// 処理中は config.IdempotencyKeyLockRenewInterval 毎に Renew でロックを延長する
+type Idempotency struct {
+	DB      QueryerAndExecer
+	Repo    IdempotencyRepository
+	Clocker clock.Clocker
+
+	mu        sync.Mutex
+	lastPurge time.Time
+}
+
+// 認証前のリクエストは全てのクライアントで user_id = 0 を共有するため、
+// リクエストの内容ごとにキーを分け、同じキーでも異なるリクエストのレスポンスは返さない
+func scopeIdempotencyKey(
+	userId entity.UserId,
+	key entity.IdempotencyKeyType,
+	requestHash string,
+) entity.IdempotencyKeyType {
+	if userId != 0 {
+		return key
+	}
+	return key + entity.IdempotencyKeyType(":"+requestHash)
+}
+
+// キーを確保できた場合は nil を返す
+//
+// 既に処理が終わっている場合は保存したレコードを返す
+// リクエストが異なる場合は entity.ErrIdempotencyKeyMismatch、
+// 処理中の場合は entity.ErrIdempotencyKeyInProgress を返す
+func (s *Idempotency) Begin(
+	ctx context.Context,
+	userId entity.UserId,
+	key entity.IdempotencyKeyType,
+	requestHash string,
+) (*entity.IdempotencyRecord, error) {
+	key = scopeIdempotencyKey(userId, key, requestHash)
+	// 期限切れのレコードを削除して作り直すため、最大 2 回試す
+	for i := 0; i < 2; i++ {
+		now := s.Clocker.Now()
+		err := s.Repo.CreateIdempotencyRecord(ctx, s.DB, &entity.IdempotencyRecord{
+			UserId:      userId,
+			Key:         key,
+			RequestHash: requestHash,
+			LockedUntil: now.Add(config.IdempotencyKeyLockTimeout),
+			ExpiresAt:   now.Add(config.IdempotencyKeyLifetime),
+		})
+		if err == nil {
+			s.purgeExpired(ctx, now)
+			return nil, nil
+		}
+		if !errors.Is(err, ErrAlreadyEntry) {
+			return nil, fmt.Errorf("Begin: %w", err)
+		}
+
+		record, err := s.Repo.GetIdempotencyRecord(ctx, s.DB, userId, key)
+		if errors.Is(err, sql.ErrNoRows) {
+			// 作成後に削除された
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Begin: %w", err)
+		}
+
+		if !record.IsExpired(now) && !record.IsLockExpired(now) {
+			if record.RequestHash != requestHash {
+				return nil, fmt.Errorf("Begin: %w", &entity.ErrIdempotencyKeyMismatch{})
+			}
+			if !record.IsCompleted() {
+				return nil, fmt.Errorf("Begin: %w", &entity.ErrIdempotencyKeyInProgress{})
+			}
+			return record, nil
+		}
+
+		if err := s.Repo.DeleteIdempotencyRecord(ctx, s.DB, userId, key); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("Begin: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("Begin: %w", &entity.ErrIdempotencyKeyInProgress{})
+}
+
+// 同じキーが再利用されない限り期限切れのレコードが残り続けるため、定期的に少しずつ削除する
+//
+// 削除に失敗してもリクエストは失敗させない
+func (s *Idempotency) purgeExpired(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	if !s.lastPurge.IsZero() && now.Sub(s.lastPurge) < config.IdempotencyKeyPurgeInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastPurge = now
+	s.mu.Unlock()
+
+	if _, err := s.Repo.DeleteExpiredIdempotencyRecords(ctx, s.DB, now, config.IdempotencyKeyPurgeLimit); err != nil {
+		log.Printf("failed to purge expired idempotency keys: %v", err)
+	}
+}
+
+func (s *Idempotency) Renew(
+	ctx context.Context,
+	userId entity.UserId,
+	key entity.IdempotencyKeyType,
+	requestHash string,
+) error {
+	key = scopeIdempotencyKey(userId, key, requestHash)
+	lockedUntil := s.Clocker.Now().Add(config.IdempotencyKeyLockTimeout)
+	if err := s.Repo.RenewIdempotencyRecord(ctx, s.DB, userId, key, lockedUntil); err != nil {
+		return fmt.Errorf("Renew: %w", err)
+	}
+	return nil
+}
+
+func (s *Idempotency) Complete(
+	ctx context.Context,
+	userId entity.UserId,
+	key entity.IdempotencyKeyType,
+	requestHash string,
+	statusCode int,
+	responseBody []byte,
+) error {
+	key = scopeIdempotencyKey(userId, key, requestHash)
+	if err := s.Repo.CompleteIdempotencyRecord(ctx, s.DB, userId, key, statusCode, responseBody); err != nil {
+		return fmt.Errorf("Complete: %w", err)
+	}
+	return nil
+}
+
+// キーを解放し、同じキーでの再送を再度処理させる
+func (s *Idempotency) Abort(
+	ctx context.Context,
+	userId entity.UserId,
+	key entity.IdempotencyKeyType,
+	requestHash string,
+) error {
+	key = scopeIdempotencyKey(userId, key, requestHash)
+	if err := s.Repo.DeleteIdempotencyRecord(ctx, s.DB, userId, key); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("Abort: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+func TestIdempotencyBegin(t *testing.T) {
+	t.Parallel()
+
+	now := clock.FixedClocker{}.Now()
+	completed := &entity.IdempotencyRecord{
+		UserId:       1,
+		Key:          "key",
+		RequestHash:  "hash",
+		StatusCode:   200,
+		ResponseBody: []byte(`{}`),
+		ExpiresAt:    now.Add(time.Hour),
+		CreatedAt:    now.Add(-time.Hour),
+	}
+
+	type want struct {
+		record     *entity.IdempotencyRecord
+		err        error
+		createdNum int
+		deletedNum int
+	}
+	tests := map[string]struct {
+		// nil の場合は既存のレコードが無い
+		existing *entity.IdempotencyRecord
+		hash     string
+		want     want
+	}{
+		"ok_new": {
+			hash: "hash",
+			want: want{createdNum: 1},
+		},
+		"ok_replay": {
+			existing: completed,
+			hash:     "hash",
+			want:     want{record: completed, createdNum: 1},
+		},
+		"ok_expired": {
+			existing: &entity.IdempotencyRecord{
+				RequestHash: "other",
+				StatusCode:  200,
+				ExpiresAt:   now,
+			},
+			hash: "hash",
+			want: want{createdNum: 2, deletedNum: 1},
+		},
+		"ok_stale_in_progress": {
+			existing: &entity.IdempotencyRecord{
+				RequestHash: "hash",
+				LockedUntil: now,
+				ExpiresAt:   now.Add(time.Hour),
+				CreatedAt:   now.Add(-config.IdempotencyKeyLockTimeout),
+			},
+			hash: "hash",
+			want: want{createdNum: 2, deletedNum: 1},
+		},
+		"ng_mismatch": {
+			existing: completed,
+			hash:     "other",
+			want:     want{err: &entity.ErrIdempotencyKeyMismatch{}, createdNum: 1},
+		},
+		"ng_in_progress": {
+			// ロックを延長し続けている場合は IdempotencyKeyLockTimeout より長く処理していても再度処理しない
+			existing: &entity.IdempotencyRecord{
+				RequestHash: "hash",
+				LockedUntil: now.Add(time.Second),
+				ExpiresAt:   now.Add(time.Hour),
+				CreatedAt:   now.Add(-10 * config.IdempotencyKeyLockTimeout),
+			},
+			hash: "hash",
+			want: want{err: &entity.ErrIdempotencyKeyInProgress{}, createdNum: 1},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			existing := tt.existing
+			moq := &IdempotencyRepositoryMock{}
+			moq.CreateIdempotencyRecordFunc = func(_ context.Context, _ Execer, _ *entity.IdempotencyRecord) error {
+				if existing != nil {
+					return ErrAlreadyEntry
+				}
+				return nil
+			}
+			moq.GetIdempotencyRecordFunc = func(_ context.Context, _ Queryer, _ entity.UserId, _ entity.IdempotencyKeyType) (*entity.IdempotencyRecord, error) {
+				return existing, nil
+			}
+			moq.DeleteIdempotencyRecordFunc = func(_ context.Context, _ Execer, _ entity.UserId, _ entity.IdempotencyKeyType) error {
+				existing = nil
+				return nil
+			}
+			moq.DeleteExpiredIdempotencyRecordsFunc = func(_ context.Context, _ Execer, _ time.Time, _ int) (int64, error) {
+				return 0, nil
+			}
+
+			s := &Idempotency{Repo: moq, Clocker: clock.FixedClocker{}}
+			got, err := s.Begin(context.Background(), 1, "key", tt.hash)
+			if tt.want.err != nil {
+				if err == nil || errors.Unwrap(err).Error() != tt.want.err.Error() {
+					t.Fatalf("want error %v, but got %v", tt.want.err, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(got, tt.want.record); len(d) != 0 {
+				t.Errorf("differs: (-got +want)\n%s", d)
+			}
+			if n := len(moq.CreateIdempotencyRecordCalls()); n != tt.want.createdNum {
+				t.Errorf("want %d creations, but got %d", tt.want.createdNum, n)
+			}
+			if n := len(moq.DeleteIdempotencyRecordCalls()); n != tt.want.deletedNum {
+				t.Errorf("want %d deletions, but got %d", tt.want.deletedNum, n)
+			}
+		})
+	}
+}
+
+// テスト中に時刻を進められる Clocker
+type stepClocker struct {
+	now time.Time
+}
+
+func (sc *stepClocker) Now() time.Time {
+	return sc.now
+}
+
+func TestIdempotencyPurgeExpired(t *testing.T) {
+	t.Parallel()
+
+	c := &stepClocker{now: clock.FixedClocker{}.Now()}
+	moq := &IdempotencyRepositoryMock{}
+	moq.CreateIdempotencyRecordFunc = func(_ context.Context, _ Execer, _ *entity.IdempotencyRecord) error {
+		return nil
+	}
+	moq.DeleteExpiredIdempotencyRecordsFunc = func(_ context.Context, _ Execer, _ time.Time, _ int) (int64, error) {
+		return 0, errors.New("error from mock")
+	}
+	s := &Idempotency{Repo: moq, Clocker: c}
+
+	begin := func() {
+		t.Helper()
+		// 削除に失敗してもキーは確保できる
+		if _, err := s.Begin(context.Background(), 1, "key", "hash"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// config.IdempotencyKeyPurgeInterval 毎に 1 回だけ削除する
+	begin()
+	begin()
+	c.now = c.now.Add(config.IdempotencyKeyPurgeInterval - time.Second)
+	begin()
+	if n := len(moq.DeleteExpiredIdempotencyRecordsCalls()); n != 1 {
+		t.Fatalf("want 1 purge, but got %d", n)
+	}
+	c.now = c.now.Add(time.Second)
+	begin()
+
+	calls := moq.DeleteExpiredIdempotencyRecordsCalls()
+	if len(calls) != 2 {
+		t.Fatalf("want 2 purges, but got %d", len(calls))
+	}
+	if !calls[1].Now.Equal(c.now) || calls[1].Limit != config.IdempotencyKeyPurgeLimit {
+		t.Errorf("want purge before %v up to %d, but got %v up to %d", c.now, config.IdempotencyKeyPurgeLimit, calls[1].Now, calls[1].Limit)
+	}
+}
+
+func TestScopeIdempotencyKey(t *testing.T) {
+	t.Parallel()
+
+	// 認証済みのユーザーはリクエストが異なっても同じキー (ErrIdempotencyKeyMismatch にする)
+	if scopeIdempotencyKey(1, "key", "hash1") != scopeIdempotencyKey(1, "key", "hash2") {
+		t.Errorf("want same key for authenticated user")
+	}
+	// 認証前は他のクライアントのレスポンスを返さないようにリクエスト毎に分ける
+	if scopeIdempotencyKey(0, "key", "hash1") == scopeIdempotencyKey(0, "key", "hash2") {
+		t.Errorf("want different keys for anonymous requests with different bodies")
+	}
+}
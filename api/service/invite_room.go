@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// TODO: convert to //go:generate when writing tests
+type InviteRoomRepository interface {
+	GetRoom(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) (*entity.Room, error)
+	GetRoomUser(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+		userId entity.UserId,
+	) (*entity.RoomUser, error)
+	GetUserFromId(
+		ctx context.Context,
+		db Queryer,
+		userId entity.UserId,
+	) (*entity.User, error)
+	GetBlockerUserIds(
+		ctx context.Context,
+		db Queryer,
+		blockedUserId entity.UserId,
+	) ([]entity.UserId, error)
+	CreateRoomInvitation(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+		inviterUserId entity.UserId,
+		inviteeUserId entity.UserId,
+		expiresAt time.Time,
+	) error
+}
+
+type InviteRoom struct {
+	DB      QueryerAndExecer
+	Repo    InviteRoomRepository
+	Clocker clock.Clocker
+}
+
+// 待機中のルームのメンバーが他のユーザーを招待する
+//
+// 招待は config.RoomInvitationLifetime の間だけ有効
+func (ir *InviteRoom) InviteRoom(
+	ctx context.Context,
+	roomId entity.RoomId,
+	inviterUserId entity.UserId,
+	inviteeUserId entity.UserId,
+) error {
+	// helper functions
+	fail := func(err error) error {
+		return fmt.Errorf("InviteRoom: %w", err)
+	}
+
+	db := ir.DB
+
+	if inviterUserId == inviteeUserId {
+		return fail(fmt.Errorf("cannot invite yourself"))
+	}
+
+	room, err := ir.Repo.GetRoom(ctx, db, roomId)
+	if err != nil {
+		return fail(err)
+	}
+	if room.Status != entity.RoomStatusWaiting {
+		return fail(fmt.Errorf("room is not waiting: %v", room.Status))
+	}
+
+	roomUser, err := ir.Repo.GetRoomUser(ctx, db, roomId, inviterUserId)
+	if err != nil {
+		return fail(err)
+	}
+	if roomUser.Status == entity.RoomUserStatusLeaved {
+		return fail(&entity.ErrPermissionDenied{})
+	}
+
+	// 存在しないユーザーへの招待を防ぐ
+	if _, err := ir.Repo.GetUserFromId(ctx, db, inviteeUserId); err != nil {
+		return fail(err)
+	}
+
+	// 招待相手にブロックされている場合は招待できない
+	blockerUserIds, err := ir.Repo.GetBlockerUserIds(ctx, db, inviterUserId)
+	if err != nil {
+		return fail(err)
+	}
+	for _, blockerUserId := range blockerUserIds {
+		if blockerUserId == inviteeUserId {
+			return fail(&entity.ErrPermissionDenied{})
+		}
+	}
+
+	expiresAt := ir.Clocker.Now().Add(config.RoomInvitationLifetime)
+	if err := ir.Repo.CreateRoomInvitation(
+		ctx,
+		db,
+		roomId,
+		inviterUserId,
+		inviteeUserId,
+		expiresAt,
+	); err != nil {
+		return fail(err)
+	}
+	return nil
+}
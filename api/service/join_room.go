@@ -10,9 +10,9 @@ import (
 	"github.com/pollenjp/gameserver-go/api/entity"
 )
 
-// TODO: convert to //go:generate when writing tests
-// go:generate go run github.com/matryer/moq -out create_room_moq_test.go . CreateRoomRepository
+//go:generate go run github.com/matryer/moq -out join_room_moq_test.go . JoinRoomRepository
 type JoinRoomRepository interface {
+	ActiveRoomRepository
 	GetRoom(
 		ctx context.Context,
 		db Queryer,
@@ -30,18 +30,35 @@ type JoinRoomRepository interface {
 		userId entity.UserId,
 		liveDifficulty entity.LiveDifficulty,
 	) (*entity.RoomUser, error)
+	CreateRoomSpectator(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+		userId entity.UserId,
+	) (*entity.RoomUser, error)
+	GetBlockerUserIds(
+		ctx context.Context,
+		db Queryer,
+		blockedUserId entity.UserId,
+	) ([]entity.UserId, error)
 }
 
 type JoinRoom struct {
 	DB   Beginner
 	Repo JoinRoomRepository
+	// 既に別のルームに参加している場合に自動で退出させる (false の場合は entity.ErrAlreadyInRoom を返す)
+	AutoLeaveActiveRoom bool
 }
 
+// asSpectator が true の場合は観戦者として参加する (ライブ中のルームにも参加できる)
+//
+// ルームのメンバーにブロックされている場合は entity.JoinRoomResultBlocked を返す
 func (cr *JoinRoom) JoinRoom(
 	ctx context.Context,
 	roomId entity.RoomId,
 	userId entity.UserId,
 	liveDifficulty entity.LiveDifficulty,
+	asSpectator bool,
 ) (entity.JoinRoomResult, error) {
 	// helper functions
 	fail := func(err error) (entity.JoinRoomResult, error) {
@@ -59,59 +76,125 @@ func (cr *JoinRoom) JoinRoom(
 		return fail(fmt.Errorf("BeginTxx: %w", err))
 	}
 
-	room, err := cr.Repo.GetRoom(ctx, tx, roomId)
+	result, err := cr.JoinRoomInTx(ctx, tx, roomId, userId, liveDifficulty, asSpectator)
 	if err != nil {
 		return failWithRollBack(tx, err)
 	}
+	if result != entity.JoinRoomResultOk {
+		if result, err := failWithRollBack(tx, nil); err != nil {
+			return result, err
+		}
+		return result, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+
+	return entity.JoinRoomResultOk, nil
+}
+
+// JoinRoom と同じ処理を呼び出し元のトランザクションで行う (他の更新と同時に参加させる場合に使う)
+//
+// entity.JoinRoomResultOk 以外を返した場合は何も変更していないため、呼び出し元で rollback する
+func (cr *JoinRoom) JoinRoomInTx(
+	ctx context.Context,
+	tx *sqlx.Tx,
+	roomId entity.RoomId,
+	userId entity.UserId,
+	liveDifficulty entity.LiveDifficulty,
+	asSpectator bool,
+) (entity.JoinRoomResult, error) {
+	// helper functions
+	fail := func(err error) (entity.JoinRoomResult, error) {
+		return entity.JoinRoomResultOtherErr, err
+	}
+
+	room, err := cr.Repo.GetRoom(ctx, tx, roomId)
+	if err != nil {
+		return fail(err)
+	}
 
 	switch room.Status {
 	case entity.RoomStatusWaiting:
 		// do nothing
 	case entity.RoomStatusLiveStart:
-		if result, err := failWithRollBack(tx, nil); err != nil {
-			return result, err
+		if asSpectator {
+			break
 		}
 		log.Printf("room is already started: %v", room)
 		return entity.JoinRoomResultOtherErr, nil
 	case entity.RoomStatusDissolution:
-		if result, err := failWithRollBack(tx, nil); err != nil {
-			return result, err
-		}
 		log.Printf("room is already dissolution: %v", room)
 		return entity.JoinRoomResultDisbanded, nil
 	default:
-		return failWithRollBack(tx, fmt.Errorf("unknown room status: %v", room.Status))
+		return fail(fmt.Errorf("unknown room status: %v", room.Status))
 	}
 
 	// check the number of users in the room
 	roomUsers, err := cr.Repo.GetRoomUsers(ctx, tx, roomId)
 	if err != nil {
-		return failWithRollBack(tx, err)
+		return fail(err)
 	}
-	if len(roomUsers) >= config.MaxUserCount {
-		if result, err := failWithRollBack(tx, nil); err != nil {
-			return result, err
+	blockerUserIds, err := cr.Repo.GetBlockerUserIds(ctx, tx, userId)
+	if err != nil {
+		return fail(err)
+	}
+	blockers := make(map[entity.UserId]struct{}, len(blockerUserIds))
+	for _, blockerUserId := range blockerUserIds {
+		blockers[blockerUserId] = struct{}{}
+	}
+	for _, roomUser := range roomUsers {
+		if roomUser.Status == entity.RoomUserStatusLeaved {
+			continue
 		}
+		if _, ok := blockers[roomUser.UserId]; ok {
+			return entity.JoinRoomResultBlocked, nil
+		}
+	}
+
+	playerCount, spectatorCount := 0, 0
+	for _, roomUser := range roomUsers {
+		if roomUser.IsSpectator() {
+			spectatorCount++
+		} else {
+			playerCount++
+		}
+	}
+	if (!asSpectator && playerCount >= config.MaxUserCount) ||
+		(asSpectator && spectatorCount >= config.MaxSpectatorCount) {
 		return entity.JoinRoomResultRoomFull, nil
 	}
 
 	// if the user is already in the room, return the result
 	for _, roomUser := range roomUsers {
 		if roomUser.UserId == userId {
-			if result, err := failWithRollBack(tx, nil); err != nil {
-				return result, err
-			}
 			log.Printf("user is already in the room: %v", userId)
 			return entity.JoinRoomResultOtherErr, nil
 		}
 	}
 
-	if _, err := cr.Repo.CreateRoomUser(ctx, tx, room.Id, userId, liveDifficulty); err != nil {
-		return failWithRollBack(tx, err)
+	if err := ensureNoActiveRoom(ctx, tx, cr.Repo, userId, roomId, cr.AutoLeaveActiveRoom); err != nil {
+		return fail(err)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	if asSpectator {
+		if _, err := cr.Repo.CreateRoomSpectator(ctx, tx, room.Id, userId); err != nil {
+			return fail(err)
+		}
+	} else {
+		if _, err := cr.Repo.CreateRoomUser(ctx, tx, room.Id, userId, liveDifficulty); err != nil {
+			return fail(err)
+		}
+	}
+
+	if err := recordAudit(ctx, tx, cr.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionRoomJoin,
+		ActorUserId:  userId,
+		TargetRoomId: room.Id,
+		Detail:       fmt.Sprintf("live_difficulty=%d spectator=%t", liveDifficulty, asSpectator),
+	}); err != nil {
+		return fail(err)
 	}
 
 	return entity.JoinRoomResultOk, nil
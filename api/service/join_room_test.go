@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestJoinRoom(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		result entity.JoinRoomResult
+		// 観戦者として追加されるかどうか
+		spectator bool
+	}
+	tests := map[string]struct {
+		status      entity.RoomStatus
+		players     int
+		spectators  int
+		asSpectator bool
+		want        want
+	}{
+		"ok_player": {
+			status:  entity.RoomStatusWaiting,
+			players: 1,
+			want:    want{result: entity.JoinRoomResultOk},
+		},
+		"ok_player_spectators_full": {
+			// 観戦者は定員に含めない
+			status:     entity.RoomStatusWaiting,
+			players:    1,
+			spectators: config.MaxSpectatorCount,
+			want:       want{result: entity.JoinRoomResultOk},
+		},
+		"ok_spectator_live_started": {
+			// 観戦者はライブ中のルームにも参加できる
+			status:      entity.RoomStatusLiveStart,
+			players:     1,
+			asSpectator: true,
+			want:        want{result: entity.JoinRoomResultOk, spectator: true},
+		},
+		"ok_spectator_players_full": {
+			status:      entity.RoomStatusWaiting,
+			players:     config.MaxUserCount,
+			asSpectator: true,
+			want:        want{result: entity.JoinRoomResultOk, spectator: true},
+		},
+		"ng_player_live_started": {
+			status:  entity.RoomStatusLiveStart,
+			players: 1,
+			want:    want{result: entity.JoinRoomResultOtherErr},
+		},
+		"ng_player_room_full": {
+			status:  entity.RoomStatusWaiting,
+			players: config.MaxUserCount,
+			want:    want{result: entity.JoinRoomResultRoomFull},
+		},
+		"ng_spectator_room_full": {
+			status:      entity.RoomStatusWaiting,
+			players:     1,
+			spectators:  config.MaxSpectatorCount,
+			asSpectator: true,
+			want:        want{result: entity.JoinRoomResultRoomFull},
+		},
+		"ng_dissolution": {
+			status:      entity.RoomStatusDissolution,
+			asSpectator: true,
+			want:        want{result: entity.JoinRoomResultDisbanded},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			db, count := testutil.TxDB(t)
+			moq := &JoinRoomRepositoryMock{}
+			moq.GetRoomFunc = func(_ context.Context, _ Queryer, roomId entity.RoomId) (*entity.Room, error) {
+				return &entity.Room{
+					Id:         roomId,
+					LiveId:     1,
+					HostUserId: 1,
+					Status:     tt.status,
+				}, nil
+			}
+			moq.GetRoomUsersFunc = func(_ context.Context, _ Queryer, roomId entity.RoomId) ([]*entity.RoomUser, error) {
+				roomUsers := []*entity.RoomUser{}
+				for i := 0; i < tt.players; i++ {
+					roomUsers = append(roomUsers, &entity.RoomUser{
+						RoomId: roomId,
+						UserId: entity.UserId(1 + i),
+						Status: entity.RoomUserStatusWaiting,
+						Role:   entity.RoomUserRolePlayer,
+					})
+				}
+				for i := 0; i < tt.spectators; i++ {
+					roomUsers = append(roomUsers, &entity.RoomUser{
+						RoomId: roomId,
+						UserId: entity.UserId(100 + i),
+						Status: entity.RoomUserStatusWaiting,
+						Role:   entity.RoomUserRoleSpectator,
+					})
+				}
+				return roomUsers, nil
+			}
+			moq.GetBlockerUserIdsFunc = func(_ context.Context, _ Queryer, _ entity.UserId) ([]entity.UserId, error) {
+				return nil, nil
+			}
+			moq.LockUserFunc = func(_ context.Context, _ Queryer, _ entity.UserId) error {
+				return nil
+			}
+			moq.GetActiveRoomIdOfUserFunc = func(_ context.Context, _ Queryer, _ entity.UserId) (entity.RoomId, error) {
+				return 0, nil
+			}
+			moq.CreateRoomUserFunc = func(_ context.Context, _ Execer, roomId entity.RoomId, userId entity.UserId, liveDifficulty entity.LiveDifficulty) (*entity.RoomUser, error) {
+				return entity.NewRoomUser(roomId, userId, liveDifficulty), nil
+			}
+			moq.CreateRoomSpectatorFunc = func(_ context.Context, _ Execer, roomId entity.RoomId, userId entity.UserId) (*entity.RoomUser, error) {
+				roomUser := entity.NewRoomUser(roomId, userId, entity.LiveDifficultyNormal)
+				roomUser.Role = entity.RoomUserRoleSpectator
+				return roomUser, nil
+			}
+			moq.CreateAuditLogFunc = func(_ context.Context, _ Execer, _ *entity.AuditLog) error {
+				return nil
+			}
+
+			s := &JoinRoom{DB: db, Repo: moq}
+			got, err := s.JoinRoom(context.Background(), 10, 50, entity.LiveDifficultyNormal, tt.asSpectator)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want.result {
+				t.Errorf("want %v, but got %v", tt.want.result, got)
+			}
+
+			players, spectators := len(moq.CreateRoomUserCalls()), len(moq.CreateRoomSpectatorCalls())
+			if tt.want.result != entity.JoinRoomResultOk {
+				// 参加できない場合は何も追加しない
+				if players+spectators != 0 {
+					t.Errorf("want no room users created, but got %d", players+spectators)
+				}
+				if n := count.Rollbacks(); n != 1 {
+					t.Errorf("want 1 rollback, but got %d", n)
+				}
+				return
+			}
+			if tt.want.spectator && (players != 0 || spectators != 1) {
+				t.Errorf("want joined as a spectator, but got players=%d spectators=%d", players, spectators)
+			}
+			if !tt.want.spectator && (players != 1 || spectators != 0) {
+				t.Errorf("want joined as a player, but got players=%d spectators=%d", players, spectators)
+			}
+			if n := count.Commits(); n != 1 {
+				t.Errorf("want 1 commit, but got %d", n)
+			}
+		})
+	}
+}
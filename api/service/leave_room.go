@@ -7,9 +7,9 @@ import (
 	"github.com/pollenjp/gameserver-go/api/entity"
 )
 
-// TODO: convert to //go:generate when writing tests
-// go:generate go run github.com/matryer/moq -out leave_room_moq_test.go . LeaveRoomRepository
+//go:generate go run github.com/matryer/moq -out leave_room_moq_test.go . LeaveRoomRepository
 type LeaveRoomRepository interface {
+	AuditLogger
 	// RoomUser.Status を Leaved にする
 	LeaveRoom(
 		ctx context.Context,
@@ -22,6 +22,11 @@ type LeaveRoomRepository interface {
 		db Execer,
 		roomId entity.RoomId,
 	) error
+	DeleteRoomChats(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+	) error
 	GetRoomUsers(
 		ctx context.Context,
 		db Queryer,
@@ -40,30 +45,49 @@ func (cr *LeaveRoom) LeaveRoom(
 	roomId entity.RoomId,
 	userId entity.UserId,
 ) error {
-	// helper functions
-	fail := func(err error) error {
+	if err := leaveRoom(ctx, cr.DB, cr.Repo, roomId, userId); err != nil {
 		return fmt.Errorf("LeaveRoom: %w", err)
 	}
+	return nil
+}
 
-	db := cr.DB
-
-	if err := cr.Repo.LeaveRoom(ctx, db, roomId, userId); err != nil {
-		return fail(err)
+// 他のサービスのトランザクション内からも利用する
+func leaveRoom(
+	ctx context.Context,
+	db QueryerAndExecer,
+	repo LeaveRoomRepository,
+	roomId entity.RoomId,
+	userId entity.UserId,
+) error {
+	if err := repo.LeaveRoom(ctx, db, roomId, userId); err != nil {
+		return err
+	}
+	if err := recordAudit(ctx, db, repo, &entity.AuditLog{
+		Action:       entity.AuditActionRoomLeave,
+		ActorUserId:  userId,
+		TargetRoomId: roomId,
+	}); err != nil {
+		return err
 	}
 
-	roomUsers, err := cr.Repo.GetRoomUsers(ctx, db, roomId)
+	roomUsers, err := repo.GetRoomUsers(ctx, db, roomId)
 	if err != nil {
-		return fail(err)
+		return err
 	}
 	for _, roomUser := range roomUsers {
-		if roomUser.Status != entity.RoomUserStatusLeaved {
-			// まだ抜けていない人がいれば、ルームを解散しない
+		if !roomUser.IsSpectator() && roomUser.Status != entity.RoomUserStatusLeaved {
+			// まだ抜けていない人がいれば、ルームを解散しない (観戦者のみが残っている場合は解散する)
 			return nil
 		}
 	}
 
-	if err := cr.Repo.DissolveRoom(ctx, db, roomId); err != nil {
-		return fail(err)
+	if err := repo.DissolveRoom(ctx, db, roomId); err != nil {
+		return err
+	}
+
+	// チャットはルームが存在する間だけ保持する
+	if err := repo.DeleteRoomChats(ctx, db, roomId); err != nil {
+		return err
 	}
 
 	return nil
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestLeaveRoom(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		// ルームを解散してチャットを削除するかどうか
+		dissolve bool
+	}
+	tests := map[string]struct {
+		// 退出後のメンバー
+		roomUsers []*entity.RoomUser
+		want      want
+	}{
+		"ok_player_remains": {
+			roomUsers: []*entity.RoomUser{
+				{UserId: 1, Status: entity.RoomUserStatusLeaved, Role: entity.RoomUserRolePlayer},
+				{UserId: 2, Status: entity.RoomUserStatusWaiting, Role: entity.RoomUserRolePlayer},
+				{UserId: 3, Status: entity.RoomUserStatusWaiting, Role: entity.RoomUserRoleSpectator},
+			},
+			want: want{dissolve: false},
+		},
+		"ok_only_spectators_remain": {
+			// 観戦者のみが残っている場合は解散する
+			roomUsers: []*entity.RoomUser{
+				{UserId: 1, Status: entity.RoomUserStatusLeaved, Role: entity.RoomUserRolePlayer},
+				{UserId: 2, Status: entity.RoomUserStatusLeaved, Role: entity.RoomUserRolePlayer},
+				{UserId: 3, Status: entity.RoomUserStatusWaiting, Role: entity.RoomUserRoleSpectator},
+			},
+			want: want{dissolve: true},
+		},
+		"ok_all_leaved": {
+			roomUsers: []*entity.RoomUser{
+				{UserId: 1, Status: entity.RoomUserStatusLeaved, Role: entity.RoomUserRolePlayer},
+			},
+			want: want{dissolve: true},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			db, _ := testutil.TxDB(t)
+			moq := &LeaveRoomRepositoryMock{}
+			moq.LeaveRoomFunc = func(_ context.Context, _ Execer, _ entity.RoomId, _ entity.UserId) error {
+				return nil
+			}
+			moq.CreateAuditLogFunc = func(_ context.Context, _ Execer, _ *entity.AuditLog) error {
+				return nil
+			}
+			moq.GetRoomUsersFunc = func(_ context.Context, _ Queryer, _ entity.RoomId) ([]*entity.RoomUser, error) {
+				return tt.roomUsers, nil
+			}
+			moq.DissolveRoomFunc = func(_ context.Context, _ Execer, _ entity.RoomId) error {
+				return nil
+			}
+			moq.DeleteRoomChatsFunc = func(_ context.Context, _ Execer, _ entity.RoomId) error {
+				return nil
+			}
+
+			s := &LeaveRoom{DB: db, Repo: moq}
+			if err := s.LeaveRoom(context.Background(), 10, 1); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if calls := moq.LeaveRoomCalls(); len(calls) != 1 || calls[0].RoomId != 10 || calls[0].UserId != 1 {
+				t.Errorf("want user 1 leaved room 10, but got %+v", calls)
+			}
+			if got := len(moq.DissolveRoomCalls()) == 1; got != tt.want.dissolve {
+				t.Errorf("want dissolve %t, but got %t", tt.want.dissolve, got)
+			}
+			if got := len(moq.DeleteRoomChatsCalls()) == 1; got != tt.want.dissolve {
+				t.Errorf("want chats deleted %t, but got %t", tt.want.dissolve, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"golang.org/x/sync/singleflight"
+)
+
+// TODO: convert to //go:generate when writing tests
+type MaintenanceRepository interface {
+	AuditLogger
+	GetMaintenance(
+		ctx context.Context,
+		db Queryer,
+	) (*entity.Maintenance, error)
+	SetMaintenance(
+		ctx context.Context,
+		db Execer,
+		m *entity.Maintenance,
+	) error
+}
+
+// メンテナンスモードを DB に保存し、全てのサーバーで共有する
+type Maintenance struct {
+	DB      BeginnerAndQueryer
+	Repo    MaintenanceRepository
+	Clocker clock.Clocker
+
+	mu        sync.Mutex
+	cached    *entity.Maintenance
+	fetchedAt time.Time
+	// SetMaintenance で更新する毎に増やし、それ以前に始めた取得結果で上書きしないようにする
+	version uint64
+
+	group singleflight.Group
+}
+
+func getMaintenance(ctx context.Context, db Queryer, repo MaintenanceRepository) (*entity.Maintenance, error) {
+	m, err := repo.GetMaintenance(ctx, db)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &entity.Maintenance{}, nil
+	}
+	return m, err
+}
+
+// リクエスト毎に参照するため config.MaintenanceCacheTTL の間キャッシュする
+// (他のサーバーで変更した場合は最大 config.MaintenanceCacheTTL 遅れて反映される)
+//
+// 取得に失敗した場合は前回の値とエラーを返す (まだ取得できていない場合は nil)
+func (s *Maintenance) CurrentMaintenance(ctx context.Context) (*entity.Maintenance, error) {
+	now := s.Clocker.Now()
+
+	s.mu.Lock()
+	if !s.fetchedAt.IsZero() && now.Sub(s.fetchedAt) < config.MaintenanceCacheTTL {
+		m := s.cached
+		s.mu.Unlock()
+		return m, nil
+	}
+	s.mu.Unlock()
+
+	// DB への問い合わせ中はロックを持たず、同時に期限切れになったリクエストは 1 回の問い合わせを共有する
+	v, err, _ := s.group.Do("", func() (interface{}, error) {
+		s.mu.Lock()
+		version := s.version
+		s.mu.Unlock()
+
+		m, err := getMaintenance(ctx, s.DB, s.Repo)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if version != s.version {
+			// 問い合わせ中に SetMaintenance で更新された
+			return s.cached, nil
+		}
+		// DB の障害時に毎回問い合わせないように、失敗した場合も取得時刻を更新する
+		s.fetchedAt = now
+		if err != nil {
+			return s.cached, err
+		}
+		s.cached = m
+		return m, nil
+	})
+	m, _ := v.(*entity.Maintenance)
+	if err != nil {
+		return m, fmt.Errorf("CurrentMaintenance: %w", err)
+	}
+	return m, nil
+}
+
+func (s *Maintenance) GetMaintenance(ctx context.Context) (*entity.Maintenance, error) {
+	var m *entity.Maintenance
+	err := runAdminAction(ctx, s.DB, s.Repo, &entity.AuditLog{
+		Action: entity.AuditActionAdminGetMaintenance,
+	}, func(tx *sqlx.Tx) error {
+		var err error
+		m, err = getMaintenance(ctx, tx, s.Repo)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetMaintenance: %w", err)
+	}
+	return m, nil
+}
+
+// このサーバーには即座に反映する
+func (s *Maintenance) SetMaintenance(
+	ctx context.Context,
+	enabled bool,
+	message string,
+	endsAt *time.Time,
+) (*entity.Maintenance, error) {
+	adminName, _ := GetAdminName(ctx)
+	m := &entity.Maintenance{
+		Enabled:   enabled,
+		Message:   message,
+		EndsAt:    endsAt,
+		AdminName: adminName,
+	}
+	detail := fmt.Sprintf("enabled=%t message=%q", enabled, message)
+	if endsAt != nil {
+		detail += fmt.Sprintf(" ends_at=%s", endsAt.Format(time.RFC3339))
+	}
+	err := runAdminAction(ctx, s.DB, s.Repo, &entity.AuditLog{
+		Action: entity.AuditActionAdminSetMaintenance,
+		Detail: detail,
+	}, func(tx *sqlx.Tx) error {
+		return s.Repo.SetMaintenance(ctx, tx, m)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SetMaintenance: %w", err)
+	}
+
+	s.mu.Lock()
+	s.cached = m
+	s.fetchedAt = s.Clocker.Now()
+	s.version++
+	s.mu.Unlock()
+	return m, nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+//go:generate go run github.com/matryer/moq -out next_round_moq_test.go . NextRoundRepository
+type NextRoundRepository interface {
+	GetRoom(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) (*entity.Room, error)
+	GetRoomUsers(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) ([]*entity.RoomUser, error)
+	GetRoomSetlist(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) ([]*entity.RoomSetlistItem, error)
+	UpdateRoomRound(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+		round int,
+		liveId entity.LiveId,
+	) error
+	UpdateRoomUserStatus(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+		userId entity.UserId,
+		status entity.RoomUserStatus,
+	) error
+}
+
+type NextRound struct {
+	DB      Beginner
+	Repo    NextRoundRepository
+	Clocker clock.Clocker
+}
+
+// 全員のスコアが揃ったルームを次のラウンドへ進め、同じメンバーで Waiting 状態に戻す (host user のみ実行可能)
+//
+// config.ResultDeadline を過ぎた場合は未送信のメンバーを待たずに進める (未送信のメンバーも次のラウンドに参加する)
+//
+// 次のラウンドの楽曲は以下の順に決定する
+//
+// 1. セットリストに次のラウンドの楽曲があればその楽曲
+// 2. liveId が指定されていればその楽曲
+// 3. 現在の楽曲 (もう一度遊ぶ)
+func (nr *NextRound) NextRound(
+	ctx context.Context,
+	roomId entity.RoomId,
+	hostUserId entity.UserId,
+	liveId entity.LiveId,
+) (*entity.Room, error) {
+	// helper functions
+	fail := func(err error) (*entity.Room, error) {
+		return nil, err
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) (*entity.Room, error) {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	tx, err := nr.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	room, err := nr.Repo.GetRoom(ctx, tx, roomId)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if room.HostUserId != hostUserId {
+		return failWithRollBack(tx, fmt.Errorf("hostUser mismatch: %v != %v", room.HostUserId, hostUserId))
+	}
+
+	if room.Status != entity.RoomStatusLiveStart {
+		return failWithRollBack(tx, fmt.Errorf("room status is not live start: %v", room.Status))
+	}
+
+	roomUsers, err := nr.Repo.GetRoomUsers(ctx, tx, roomId)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+	deadlinePassed := isResultDeadlinePassed(room, nr.Clocker.Now())
+	for _, roomUser := range roomUsers {
+		if !roomUser.IsSpectator() && roomUser.Status == entity.RoomUserStatusWaiting && !deadlinePassed {
+			return failWithRollBack(tx, fmt.Errorf("user has not finished the live yet: %v", roomUser.UserId))
+		}
+	}
+
+	setlist, err := nr.Repo.GetRoomSetlist(ctx, tx, roomId)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	nextRound := room.Round + 1
+	nextLiveId := room.LiveId
+	if liveId != entity.LiveId(0) {
+		nextLiveId = liveId
+	}
+	for _, item := range setlist {
+		if item.Round == nextRound {
+			nextLiveId = item.LiveId
+			break
+		}
+	}
+
+	if err := nr.Repo.UpdateRoomRound(ctx, tx, roomId, nextRound, nextLiveId); err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	// 退出したユーザーは次のラウンドに参加しない
+	for _, roomUser := range roomUsers {
+		if roomUser.Status != entity.RoomUserStatusFinished {
+			continue
+		}
+		if err := nr.Repo.UpdateRoomUserStatus(ctx, tx, roomId, roomUser.UserId, entity.RoomUserStatusWaiting); err != nil {
+			return failWithRollBack(tx, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+
+	room.Round = nextRound
+	room.LiveId = nextLiveId
+	room.Status = entity.RoomStatusWaiting
+	return room, nil
+}
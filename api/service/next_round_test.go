@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestNextRound(t *testing.T) {
+	t.Parallel()
+
+	now := clock.FixedClocker{}.Now()
+
+	type want struct {
+		err bool
+		// 次のラウンドで Waiting に戻すユーザー
+		reset []entity.UserId
+	}
+	tests := map[string]struct {
+		startAt time.Time
+		want    want
+	}{
+		"ng_waiting_member": {
+			// 期限内は未送信のメンバーを待つ
+			startAt: now.Add(-config.ResultDeadline),
+			want:    want{err: true},
+		},
+		"ok_deadline_passed": {
+			// 期限を過ぎた場合は未送信のメンバーを待たない (未送信のメンバーは Waiting のまま)
+			startAt: now.Add(-config.ResultDeadline - time.Second),
+			want:    want{reset: []entity.UserId{1}},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			db, _ := testutil.TxDB(t)
+			moq := &NextRoundRepositoryMock{}
+			moq.GetRoomFunc = func(_ context.Context, _ Queryer, roomId entity.RoomId) (*entity.Room, error) {
+				startAt := tt.startAt
+				return &entity.Room{
+					Id:         roomId,
+					LiveId:     1,
+					HostUserId: 1,
+					Status:     entity.RoomStatusLiveStart,
+					Round:      1,
+					StartAt:    &startAt,
+				}, nil
+			}
+			moq.GetRoomUsersFunc = func(_ context.Context, _ Queryer, roomId entity.RoomId) ([]*entity.RoomUser, error) {
+				return []*entity.RoomUser{
+					{RoomId: roomId, UserId: 1, Status: entity.RoomUserStatusFinished},
+					{RoomId: roomId, UserId: 2, Status: entity.RoomUserStatusWaiting},
+				}, nil
+			}
+			moq.GetRoomSetlistFunc = func(_ context.Context, _ Queryer, _ entity.RoomId) ([]*entity.RoomSetlistItem, error) {
+				return nil, nil
+			}
+			moq.UpdateRoomRoundFunc = func(_ context.Context, _ Execer, _ entity.RoomId, _ int, _ entity.LiveId) error {
+				return nil
+			}
+			moq.UpdateRoomUserStatusFunc = func(_ context.Context, _ Execer, _ entity.RoomId, _ entity.UserId, _ entity.RoomUserStatus) error {
+				return nil
+			}
+
+			s := &NextRound{DB: db, Repo: moq, Clocker: clock.FixedClocker{}}
+			got, err := s.NextRound(context.Background(), 10, 1, 0)
+			if tt.want.err {
+				if err == nil {
+					t.Fatal("want error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Round != 2 || got.Status != entity.RoomStatusWaiting {
+				t.Errorf("want round 2 and waiting, but got round %d and status %v", got.Round, got.Status)
+			}
+			calls := moq.UpdateRoomUserStatusCalls()
+			if len(calls) != len(tt.want.reset) {
+				t.Fatalf("want %d status updates, but got %d", len(tt.want.reset), len(calls))
+			}
+			for i, c := range calls {
+				if c.UserId != tt.want.reset[i] {
+					t.Errorf("want user %d reset, but got %d", tt.want.reset[i], c.UserId)
+				}
+			}
+		})
+	}
+}
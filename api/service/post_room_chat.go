@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+//go:generate go run github.com/matryer/moq -out post_room_chat_moq_test.go . PostRoomChatRepository
+type PostRoomChatRepository interface {
+	GetRoomUser(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+		userId entity.UserId,
+	) (*entity.RoomUser, error)
+	CountRoomChatsByUserSince(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+		userId entity.UserId,
+		since time.Time,
+	) (int, error)
+	CreateRoomChat(
+		ctx context.Context,
+		db Execer,
+		chat *entity.RoomChat,
+	) error
+}
+
+type PostRoomChat struct {
+	DB      QueryerAndExecer
+	Repo    PostRoomChatRepository
+	Clocker clock.Clocker
+}
+
+// ルームのメンバー (観戦者を含む) がチャットを送信する
+//
+// config.ChatRateLimitWindow の間に config.ChatRateLimitCount を超えて送信した場合は entity.ErrTooManyRequests を返す
+func (pc *PostRoomChat) PostRoomChat(
+	ctx context.Context,
+	chat *entity.RoomChat,
+) (*entity.RoomChat, error) {
+	// helper functions
+	fail := func(err error) (*entity.RoomChat, error) {
+		return nil, fmt.Errorf("PostRoomChat: %w", err)
+	}
+
+	db := pc.DB
+
+	roomUser, err := pc.Repo.GetRoomUser(ctx, db, chat.RoomId, chat.UserId)
+	if err != nil {
+		return fail(err)
+	}
+	if roomUser.Status == entity.RoomUserStatusLeaved {
+		return fail(&entity.ErrPermissionDenied{})
+	}
+
+	since := pc.Clocker.Now().Add(-config.ChatRateLimitWindow)
+	count, err := pc.Repo.CountRoomChatsByUserSince(ctx, db, chat.RoomId, chat.UserId, since)
+	if err != nil {
+		return fail(err)
+	}
+	if count >= config.ChatRateLimitCount {
+		return fail(&entity.ErrTooManyRequests{})
+	}
+
+	if err := pc.Repo.CreateRoomChat(ctx, db, chat); err != nil {
+		return fail(err)
+	}
+	return chat, nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestPostRoomChat(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err error
+	}
+	tests := map[string]struct {
+		status entity.RoomUserStatus
+		// config.ChatRateLimitWindow の間に送信済みのチャット数
+		count int
+		want  want
+	}{
+		"ok": {
+			status: entity.RoomUserStatusWaiting,
+			count:  config.ChatRateLimitCount - 1,
+			want:   want{},
+		},
+		"ng_rate_limited": {
+			status: entity.RoomUserStatusWaiting,
+			count:  config.ChatRateLimitCount,
+			want:   want{err: &entity.ErrTooManyRequests{}},
+		},
+		"ng_leaved": {
+			status: entity.RoomUserStatusLeaved,
+			want:   want{err: &entity.ErrPermissionDenied{}},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			db, _ := testutil.TxDB(t)
+			moq := &PostRoomChatRepositoryMock{}
+			moq.GetRoomUserFunc = func(_ context.Context, _ Queryer, roomId entity.RoomId, userId entity.UserId) (*entity.RoomUser, error) {
+				return &entity.RoomUser{RoomId: roomId, UserId: userId, Status: tt.status}, nil
+			}
+			moq.CountRoomChatsByUserSinceFunc = func(_ context.Context, _ Queryer, _ entity.RoomId, _ entity.UserId, _ time.Time) (int, error) {
+				return tt.count, nil
+			}
+			moq.CreateRoomChatFunc = func(_ context.Context, _ Execer, _ *entity.RoomChat) error {
+				return nil
+			}
+
+			s := &PostRoomChat{DB: db, Repo: moq, Clocker: clock.FixedClocker{}}
+			_, err := s.PostRoomChat(context.Background(), &entity.RoomChat{RoomId: 10, UserId: 1, Message: "hello"})
+			if tt.want.err != nil {
+				if err == nil || errors.Unwrap(err).Error() != tt.want.err.Error() {
+					t.Fatalf("want error %v, but got %v", tt.want.err, err)
+				}
+				if n := len(moq.CreateRoomChatCalls()); n != 0 {
+					t.Errorf("want no chat created, but got %d", n)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// 直近 config.ChatRateLimitWindow の送信数を数える
+			want := clock.FixedClocker{}.Now().Add(-config.ChatRateLimitWindow)
+			if calls := moq.CountRoomChatsByUserSinceCalls(); len(calls) != 1 || !calls[0].Since.Equal(want) {
+				t.Errorf("want counted since %v, but got %+v", want, calls)
+			}
+			if n := len(moq.CreateRoomChatCalls()); n != 1 {
+				t.Errorf("want 1 chat created, but got %d", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+//go:generate go run github.com/matryer/moq -out rematch_moq_test.go . RematchRepository
+type RematchRepository interface {
+	CreateRoomRepository
+	GetRoom(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) (*entity.Room, error)
+	GetRoomUsers(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) ([]*entity.RoomUser, error)
+	UpdateRoomRematchRoomId(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+		rematchRoomId entity.RoomId,
+	) error
+}
+
+type Rematch struct {
+	DB      Beginner
+	Repo    RematchRepository
+	Clocker clock.Clocker
+	// host user が既に別のルームに参加している場合に自動で退出させる (false の場合は entity.ErrAlreadyInRoom を返す)
+	AutoLeaveActiveRoom bool
+}
+
+// 終了したルームと同じメンバーで新しいルームを作成する
+//
+// - 実行したユーザーが新しいルームの host user になる
+// - `/room/rematch_opt_in` で再戦を希望したメンバーは新しいルームに参加させる
+// - liveId に 0 を指定した場合は同じ楽曲で再戦する
+// - config.ResultDeadline を過ぎた場合は未送信のメンバーを待たずに作成する (未送信のメンバーは参加させない)
+// - 既に別のルームに参加しているメンバーは新しいルームに参加させない
+func (rm *Rematch) Rematch(
+	ctx context.Context,
+	roomId entity.RoomId,
+	hostUserId entity.UserId,
+	liveId entity.LiveId,
+	liveDifficulty entity.LiveDifficulty,
+) (*entity.Room, error) {
+	// helper functions
+	fail := func(err error) (*entity.Room, error) {
+		return nil, err
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) (*entity.Room, error) {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	tx, err := rm.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	room, err := rm.Repo.GetRoom(ctx, tx, roomId)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if room.Status != entity.RoomStatusLiveStart {
+		return failWithRollBack(tx, fmt.Errorf("room status is not live start: %v", room.Status))
+	}
+
+	if room.RematchRoomId != entity.RoomId(0) {
+		return failWithRollBack(tx, fmt.Errorf("rematch room is already created: %v", room.RematchRoomId))
+	}
+
+	roomUsers, err := rm.Repo.GetRoomUsers(ctx, tx, roomId)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	deadlinePassed := isResultDeadlinePassed(room, rm.Clocker.Now())
+	isMember := false
+	for _, roomUser := range roomUsers {
+		if !roomUser.IsSpectator() && roomUser.Status == entity.RoomUserStatusWaiting && !deadlinePassed {
+			return failWithRollBack(tx, fmt.Errorf("user has not finished the live yet: %v", roomUser.UserId))
+		}
+		if roomUser.UserId == hostUserId && roomUser.Status == entity.RoomUserStatusFinished {
+			isMember = true
+		}
+	}
+	if !isMember {
+		return failWithRollBack(tx, &entity.ErrPermissionDenied{})
+	}
+
+	if liveId == entity.LiveId(0) {
+		liveId = room.LiveId
+	}
+
+	// 終了したルームは除いて確認する
+	if err := ensureNoActiveRoom(ctx, tx, rm.Repo, hostUserId, roomId, rm.AutoLeaveActiveRoom); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("Rematch: %w", err))
+	}
+
+	newRoom, err := rm.Repo.CreateRoom(ctx, tx, liveId, hostUserId)
+	if err != nil {
+		return failWithRollBack(tx, fmt.Errorf("CreateRoom: %w", err))
+	}
+
+	if _, err := rm.Repo.CreateRoomUser(ctx, tx, newRoom.Id, hostUserId, liveDifficulty); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("CreateRoomUser: %w", err))
+	}
+
+	for _, roomUser := range roomUsers {
+		if roomUser.UserId == hostUserId || !roomUser.Rematch || roomUser.Status != entity.RoomUserStatusFinished {
+			continue
+		}
+		if err := ensureNoActiveRoom(ctx, tx, rm.Repo, roomUser.UserId, roomId, false); err != nil {
+			if errors.As(err, new(*entity.ErrAlreadyInRoom)) {
+				log.Printf("user is already in another room: %v: %v", roomUser.UserId, err)
+				continue
+			}
+			return failWithRollBack(tx, fmt.Errorf("Rematch: %w", err))
+		}
+		if _, err := rm.Repo.CreateRoomUser(ctx, tx, newRoom.Id, roomUser.UserId, roomUser.LiveDifficulty); err != nil {
+			return failWithRollBack(tx, fmt.Errorf("CreateRoomUser: %w", err))
+		}
+	}
+
+	if err := rm.Repo.UpdateRoomRematchRoomId(ctx, tx, roomId, newRoom.Id); err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+
+	return newRoom, nil
+}
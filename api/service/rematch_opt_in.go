@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+//go:generate go run github.com/matryer/moq -out rematch_opt_in_moq_test.go . RematchOptInRepository
+type RematchOptInRepository interface {
+	GetRoom(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) (*entity.Room, error)
+	GetRoomUser(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+		userId entity.UserId,
+	) (*entity.RoomUser, error)
+	UpdateRoomUserRematch(
+		ctx context.Context,
+		db Execer,
+		roomId entity.RoomId,
+		userId entity.UserId,
+		rematch bool,
+	) error
+}
+
+type RematchOptIn struct {
+	DB   QueryerAndExecer
+	Repo RematchOptInRepository
+}
+
+// 再戦の希望を登録する
+//
+// 再戦ルームが作成済みの場合は `/room/wait` で返される rematch_room_id に `/room/join` する
+// ライブを終えた (結果を送信した) メンバーのみ登録でき、それ以外は entity.ErrPermissionDenied を返す
+func (ro *RematchOptIn) RematchOptIn(
+	ctx context.Context,
+	roomId entity.RoomId,
+	userId entity.UserId,
+	rematch bool,
+) error {
+	// helper functions
+	fail := func(err error) error {
+		return fmt.Errorf("RematchOptIn: %w", err)
+	}
+
+	db := ro.DB
+
+	room, err := ro.Repo.GetRoom(ctx, db, roomId)
+	if err != nil {
+		return fail(err)
+	}
+
+	if room.Status != entity.RoomStatusLiveStart {
+		return fail(fmt.Errorf("room status is not live start: %v", room.Status))
+	}
+
+	if room.RematchRoomId != entity.RoomId(0) {
+		return fail(fmt.Errorf("rematch room is already created: %v", room.RematchRoomId))
+	}
+
+	// 観戦者・退出したユーザー・ライブ中のユーザーは再戦ルームに参加しない
+	roomUser, err := ro.Repo.GetRoomUser(ctx, db, roomId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fail(&entity.ErrPermissionDenied{})
+	}
+	if err != nil {
+		return fail(err)
+	}
+	if roomUser.IsSpectator() || roomUser.Status != entity.RoomUserStatusFinished {
+		return fail(&entity.ErrPermissionDenied{})
+	}
+
+	if err := ro.Repo.UpdateRoomUserRematch(ctx, db, roomId, userId, rematch); err != nil {
+		return fail(err)
+	}
+
+	return nil
+}
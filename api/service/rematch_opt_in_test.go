@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+func TestRematchOptIn(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		roomStatus entity.RoomStatus
+		// nil の場合はルームのメンバーではない
+		roomUser *entity.RoomUser
+		// entity.ErrPermissionDenied 以外のエラーも含む
+		wantErr    bool
+		wantDenied bool
+	}{
+		"ok": {
+			roomStatus: entity.RoomStatusLiveStart,
+			roomUser:   &entity.RoomUser{Status: entity.RoomUserStatusFinished},
+		},
+		"ng_not_member": {
+			roomStatus: entity.RoomStatusLiveStart,
+			wantErr:    true,
+			wantDenied: true,
+		},
+		"ng_spectator": {
+			roomStatus: entity.RoomStatusLiveStart,
+			roomUser:   &entity.RoomUser{Status: entity.RoomUserStatusFinished, Role: entity.RoomUserRoleSpectator},
+			wantErr:    true,
+			wantDenied: true,
+		},
+		"ng_not_finished": {
+			roomStatus: entity.RoomStatusLiveStart,
+			roomUser:   &entity.RoomUser{Status: entity.RoomUserStatusWaiting},
+			wantErr:    true,
+			wantDenied: true,
+		},
+		"ng_room_waiting": {
+			roomStatus: entity.RoomStatusWaiting,
+			roomUser:   &entity.RoomUser{Status: entity.RoomUserStatusFinished},
+			wantErr:    true,
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			moq := &RematchOptInRepositoryMock{}
+			moq.GetRoomFunc = func(_ context.Context, _ Queryer, roomId entity.RoomId) (*entity.Room, error) {
+				return &entity.Room{Id: roomId, Status: tt.roomStatus}, nil
+			}
+			moq.GetRoomUserFunc = func(_ context.Context, _ Queryer, _ entity.RoomId, _ entity.UserId) (*entity.RoomUser, error) {
+				if tt.roomUser == nil {
+					return nil, fmt.Errorf("GetRoomUser: %w", sql.ErrNoRows)
+				}
+				return tt.roomUser, nil
+			}
+			moq.UpdateRoomUserRematchFunc = func(_ context.Context, _ Execer, _ entity.RoomId, _ entity.UserId, _ bool) error {
+				return nil
+			}
+
+			s := &RematchOptIn{Repo: moq}
+			err := s.RematchOptIn(context.Background(), 10, 1, true)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, but got %v", tt.wantErr, err)
+			}
+			if denied := errors.As(err, new(*entity.ErrPermissionDenied)); denied != tt.wantDenied {
+				t.Errorf("want permission denied %v, but got %v", tt.wantDenied, err)
+			}
+			if n := len(moq.UpdateRoomUserRematchCalls()); (n == 1) != !tt.wantErr {
+				t.Errorf("unexpected number of updates: %d", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestRematch(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err error
+		// 新しいルームに参加させるユーザー
+		joined []entity.UserId
+		// 退出させるルーム
+		leaved []entity.RoomId
+	}
+	tests := map[string]struct {
+		// 終了したルーム (10) 以外に参加しているルーム
+		activeRooms map[entity.UserId]entity.RoomId
+		autoLeave   bool
+		want        want
+	}{
+		"ok": {
+			want: want{joined: []entity.UserId{1, 2, 3}},
+		},
+		"ok_member_in_other_room": {
+			// 既に別のルームに参加しているメンバーは参加させない
+			activeRooms: map[entity.UserId]entity.RoomId{3: 30},
+			want:        want{joined: []entity.UserId{1, 2}},
+		},
+		"ok_host_auto_leave": {
+			activeRooms: map[entity.UserId]entity.RoomId{1: 30},
+			autoLeave:   true,
+			want:        want{joined: []entity.UserId{1, 2, 3}, leaved: []entity.RoomId{30}},
+		},
+		"ng_host_in_other_room": {
+			activeRooms: map[entity.UserId]entity.RoomId{1: 30},
+			want:        want{err: &entity.ErrAlreadyInRoom{RoomId: 30}},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			db, count := testutil.TxDB(t)
+			moq := &RematchRepositoryMock{}
+			moq.GetRoomFunc = func(_ context.Context, _ Queryer, roomId entity.RoomId) (*entity.Room, error) {
+				return &entity.Room{
+					Id:         roomId,
+					LiveId:     1,
+					HostUserId: 1,
+					Status:     entity.RoomStatusLiveStart,
+				}, nil
+			}
+			moq.GetRoomUsersFunc = func(_ context.Context, _ Queryer, roomId entity.RoomId) ([]*entity.RoomUser, error) {
+				if roomId != 10 {
+					// 退出させたルームには他のメンバーが残っている
+					return []*entity.RoomUser{
+						{RoomId: roomId, UserId: 5, Status: entity.RoomUserStatusWaiting, Role: entity.RoomUserRolePlayer},
+					}, nil
+				}
+				return []*entity.RoomUser{
+					{RoomId: roomId, UserId: 1, Status: entity.RoomUserStatusFinished, Role: entity.RoomUserRolePlayer},
+					{RoomId: roomId, UserId: 2, Status: entity.RoomUserStatusFinished, Role: entity.RoomUserRolePlayer, Rematch: true},
+					{RoomId: roomId, UserId: 3, Status: entity.RoomUserStatusFinished, Role: entity.RoomUserRolePlayer, Rematch: true},
+					{RoomId: roomId, UserId: 4, Status: entity.RoomUserStatusFinished, Role: entity.RoomUserRolePlayer},
+				}, nil
+			}
+			moq.LockUserFunc = func(_ context.Context, _ Queryer, _ entity.UserId) error {
+				return nil
+			}
+			moq.GetActiveRoomIdOfUserFunc = func(_ context.Context, _ Queryer, userId entity.UserId) (entity.RoomId, error) {
+				if roomId, ok := tt.activeRooms[userId]; ok {
+					return roomId, nil
+				}
+				// 再戦の作成までは終了したルームに参加している
+				return 10, nil
+			}
+			moq.LeaveRoomFunc = func(_ context.Context, _ Execer, _ entity.RoomId, _ entity.UserId) error {
+				return nil
+			}
+			moq.CreateAuditLogFunc = func(_ context.Context, _ Execer, _ *entity.AuditLog) error {
+				return nil
+			}
+			moq.CreateRoomFunc = func(_ context.Context, _ Execer, liveId entity.LiveId, hostUserId entity.UserId) (*entity.Room, error) {
+				return &entity.Room{Id: 20, LiveId: liveId, HostUserId: hostUserId, Status: entity.RoomStatusWaiting}, nil
+			}
+			moq.CreateRoomUserFunc = func(_ context.Context, _ Execer, roomId entity.RoomId, userId entity.UserId, liveDifficulty entity.LiveDifficulty) (*entity.RoomUser, error) {
+				return entity.NewRoomUser(roomId, userId, liveDifficulty), nil
+			}
+			moq.UpdateRoomRematchRoomIdFunc = func(_ context.Context, _ Execer, _ entity.RoomId, _ entity.RoomId) error {
+				return nil
+			}
+
+			s := &Rematch{DB: db, Repo: moq, Clocker: clock.FixedClocker{}, AutoLeaveActiveRoom: tt.autoLeave}
+			_, err := s.Rematch(context.Background(), 10, 1, 0, entity.LiveDifficultyNormal)
+			if tt.want.err != nil {
+				if err == nil || errors.Unwrap(err).Error() != tt.want.err.Error() {
+					t.Fatalf("want error %v, but got %v", tt.want.err, err)
+				}
+				if n := len(moq.CreateRoomCalls()); n != 0 {
+					t.Errorf("want no room created, but got %d", n)
+				}
+				if n := count.Rollbacks(); n != 1 {
+					t.Errorf("want 1 rollback, but got %d", n)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var joined []entity.UserId
+			for _, c := range moq.CreateRoomUserCalls() {
+				joined = append(joined, c.UserId)
+			}
+			if d := cmp.Diff(tt.want.joined, joined); d != "" {
+				t.Errorf("joined users differ (-want +got):\n%s", d)
+			}
+			// host user と再戦を希望したメンバーはロックしてから確認する
+			var locked []entity.UserId
+			for _, c := range moq.LockUserCalls() {
+				locked = append(locked, c.UserId)
+			}
+			if d := cmp.Diff([]entity.UserId{1, 2, 3}, locked); d != "" {
+				t.Errorf("locked users differ (-want +got):\n%s", d)
+			}
+			var leaved []entity.RoomId
+			for _, c := range moq.LeaveRoomCalls() {
+				leaved = append(leaved, c.RoomId)
+			}
+			if d := cmp.Diff(tt.want.leaved, leaved); d != "" {
+				t.Errorf("leaved rooms differ (-want +got):\n%s", d)
+			}
+			if n := count.Commits(); n != 1 {
+				t.Errorf("want 1 commit, but got %d", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// TODO: convert to //go:generate when writing tests
+type RemoveFriendRepository interface {
+	DeleteFriend(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+		friendUserId entity.UserId,
+	) error
+}
+
+type RemoveFriend struct {
+	DB   Execer
+	Repo RemoveFriendRepository
+}
+
+// フレンドの解除に加えて、送信・受信したフレンド申請の取り消し・拒否にも利用する
+func (rf *RemoveFriend) RemoveFriend(
+	ctx context.Context,
+	userId entity.UserId,
+	friendUserId entity.UserId,
+) error {
+	if err := rf.Repo.DeleteFriend(ctx, rf.DB, userId, friendUserId); err != nil {
+		return fmt.Errorf("RemoveFriend: %w", err)
+	}
+	return nil
+}
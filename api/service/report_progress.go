@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// ライブの途中経過の保存先
+type LiveProgressStore interface {
+	SetProgress(
+		roomId entity.RoomId,
+		round int,
+		progress *entity.LiveProgress,
+	)
+	GetProgressList(
+		roomId entity.RoomId,
+		round int,
+	) []*entity.LiveProgress
+}
+
+// TODO: convert to //go:generate when writing tests
+type ReportProgressRepository interface {
+	GetRoom(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) (*entity.Room, error)
+	GetRoomUsers(
+		ctx context.Context,
+		db Queryer,
+		roomId entity.RoomId,
+	) ([]*entity.RoomUser, error)
+}
+
+type ReportProgress struct {
+	DB    Queryer
+	Repo  ReportProgressRepository
+	Store LiveProgressStore
+}
+
+// ライブ中 (LiveStart かつ `/room/end` 前) のメンバーの途中経過を保存する
+func (rp *ReportProgress) ReportProgress(
+	ctx context.Context,
+	roomId entity.RoomId,
+	progress *entity.LiveProgress,
+) error {
+	// helper functions
+	fail := func(err error) error {
+		return fmt.Errorf("ReportProgress: %w", err)
+	}
+
+	db := rp.DB
+
+	room, err := rp.Repo.GetRoom(ctx, db, roomId)
+	if err != nil {
+		return fail(err)
+	}
+
+	if room.Status != entity.RoomStatusLiveStart {
+		return fail(fmt.Errorf("room status is not live start: %v", room.Status))
+	}
+
+	roomUsers, err := rp.Repo.GetRoomUsers(ctx, db, roomId)
+	if err != nil {
+		return fail(err)
+	}
+
+	isPlaying := false
+	for _, roomUser := range roomUsers {
+		if roomUser.UserId == progress.UserId && !roomUser.IsSpectator() && roomUser.Status == entity.RoomUserStatusWaiting {
+			isPlaying = true
+			break
+		}
+	}
+	if !isPlaying {
+		return fail(&entity.ErrPermissionDenied{})
+	}
+
+	rp.Store.SetProgress(roomId, room.Round, progress)
+	return nil
+}
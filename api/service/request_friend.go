@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+//go:generate go run github.com/matryer/moq -out request_friend_moq_test.go . RequestFriendRepository
+type RequestFriendRepository interface {
+	GetUserFromFriendCode(
+		ctx context.Context,
+		db Queryer,
+		friendCode entity.FriendCodeType,
+	) (*entity.User, error)
+	GetBlockerUserIds(
+		ctx context.Context,
+		db Queryer,
+		blockedUserId entity.UserId,
+	) ([]entity.UserId, error)
+	GetFriend(
+		ctx context.Context,
+		db Queryer,
+		userId entity.UserId,
+		friendUserId entity.UserId,
+	) (*entity.Friend, error)
+	UpsertFriend(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+		friendUserId entity.UserId,
+		status entity.FriendStatus,
+	) (*entity.Friend, error)
+}
+
+type RequestFriend struct {
+	DB   Beginner
+	Repo RequestFriendRepository
+}
+
+// フレンドコードで指定したユーザーにフレンド申請する
+//
+// 相手から既に申請を受けている場合はそのままフレンドになる
+// どちらかがもう一方をブロックしている場合は entity.ErrPermissionDenied を返す
+func (rf *RequestFriend) RequestFriend(
+	ctx context.Context,
+	userId entity.UserId,
+	friendCode entity.FriendCodeType,
+) (entity.FriendRelation, error) {
+	// helper functions
+	fail := func(err error) (entity.FriendRelation, error) {
+		return 0, err
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) (entity.FriendRelation, error) {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	tx, err := rf.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	target, err := rf.Repo.GetUserFromFriendCode(ctx, tx, friendCode)
+	if err != nil {
+		return failWithRollBack(tx, fmt.Errorf("GetUserFromFriendCode: %w", err))
+	}
+	if target.Id == userId {
+		return failWithRollBack(tx, fmt.Errorf("cannot send a friend request to yourself"))
+	}
+
+	// 自分 -> 相手・相手 -> 自分のどちらのブロックも確認する
+	for _, pair := range [][2]entity.UserId{{userId, target.Id}, {target.Id, userId}} {
+		blockerIds, err := rf.Repo.GetBlockerUserIds(ctx, tx, pair[1])
+		if err != nil {
+			return failWithRollBack(tx, err)
+		}
+		for _, id := range blockerIds {
+			if id == pair[0] {
+				return failWithRollBack(tx, &entity.ErrPermissionDenied{})
+			}
+		}
+	}
+
+	// 自分 -> 相手
+	if friend, err := rf.Repo.GetFriend(ctx, tx, userId, target.Id); err == nil {
+		if result, err := failWithRollBack(tx, nil); err != nil {
+			return result, err
+		}
+		if friend.Status == entity.FriendStatusAccepted {
+			return entity.FriendRelationFriend, nil
+		}
+		return entity.FriendRelationOutgoingRequest, nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return failWithRollBack(tx, err)
+	}
+
+	// 相手 -> 自分
+	relation := entity.FriendRelationOutgoingRequest
+	status := entity.FriendStatusRequested
+	if _, err := rf.Repo.GetFriend(ctx, tx, target.Id, userId); err == nil {
+		relation = entity.FriendRelationFriend
+		status = entity.FriendStatusAccepted
+		if _, err := rf.Repo.UpsertFriend(ctx, tx, target.Id, userId, status); err != nil {
+			return failWithRollBack(tx, err)
+		}
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return failWithRollBack(tx, err)
+	}
+
+	if _, err := rf.Repo.UpsertFriend(ctx, tx, userId, target.Id, status); err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+
+	return relation, nil
+}
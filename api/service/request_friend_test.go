@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+func TestRequestFriend(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		relation entity.FriendRelation
+		err      error
+		upserted int
+	}
+	tests := map[string]struct {
+		// blocked をブロックしているユーザー
+		blockers map[entity.UserId][]entity.UserId
+		// 相手から申請を受けている
+		incoming bool
+		want     want
+	}{
+		"ok": {
+			want: want{relation: entity.FriendRelationOutgoingRequest, upserted: 1},
+		},
+		"ok_incoming": {
+			incoming: true,
+			want:     want{relation: entity.FriendRelationFriend, upserted: 2},
+		},
+		"ng_blocked_by_me": {
+			blockers: map[entity.UserId][]entity.UserId{2: {1}},
+			want:     want{err: &entity.ErrPermissionDenied{}},
+		},
+		"ng_blocked_by_target": {
+			// 相手にブロックされている場合は申請を受けていても拒否する
+			blockers: map[entity.UserId][]entity.UserId{1: {2}},
+			incoming: true,
+			want:     want{err: &entity.ErrPermissionDenied{}},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			db, _ := testutil.TxDB(t)
+			moq := &RequestFriendRepositoryMock{}
+			moq.GetUserFromFriendCodeFunc = func(_ context.Context, _ Queryer, _ entity.FriendCodeType) (*entity.User, error) {
+				return &entity.User{Id: 2}, nil
+			}
+			moq.GetBlockerUserIdsFunc = func(_ context.Context, _ Queryer, blockedUserId entity.UserId) ([]entity.UserId, error) {
+				return tt.blockers[blockedUserId], nil
+			}
+			moq.GetFriendFunc = func(_ context.Context, _ Queryer, userId entity.UserId, _ entity.UserId) (*entity.Friend, error) {
+				if tt.incoming && userId == 2 {
+					return &entity.Friend{UserId: 2, FriendUserId: 1, Status: entity.FriendStatusRequested}, nil
+				}
+				return nil, fmt.Errorf("GetFriend: %w", sql.ErrNoRows)
+			}
+			moq.UpsertFriendFunc = func(_ context.Context, _ Execer, userId entity.UserId, friendUserId entity.UserId, status entity.FriendStatus) (*entity.Friend, error) {
+				return &entity.Friend{UserId: userId, FriendUserId: friendUserId, Status: status}, nil
+			}
+
+			s := &RequestFriend{DB: db, Repo: moq}
+			got, err := s.RequestFriend(context.Background(), 1, "code")
+			if tt.want.err != nil {
+				if !errors.As(err, new(*entity.ErrPermissionDenied)) {
+					t.Fatalf("want error %v, but got %v", tt.want.err, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want.relation {
+				t.Errorf("want relation %d, but got %d", tt.want.relation, got)
+			}
+			if n := len(moq.UpsertFriendCalls()); n != tt.want.upserted {
+				t.Errorf("want %d upserts, but got %d", tt.want.upserted, n)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+//go:generate go run github.com/matryer/moq -out respond_room_invitation_moq_test.go . RespondRoomInvitationRepository
+type RespondRoomInvitationRepository interface {
+	GetRoomInvitation(
+		ctx context.Context,
+		db Queryer,
+		invitationId entity.RoomInvitationId,
+	) (*entity.RoomInvitation, error)
+	// Pending の招待のみ更新する (既に回答済みの場合は sql.ErrNoRows を返す)
+	UpdateRoomInvitationStatus(
+		ctx context.Context,
+		db Execer,
+		invitationId entity.RoomInvitationId,
+		status entity.RoomInvitationStatus,
+	) error
+}
+
+// 招待を受けた際のルームへの参加は `/room/join` と同じ処理を使う (招待の更新と同じトランザクションで参加する)
+//
+//go:generate go run github.com/matryer/moq -out room_joiner_moq_test.go . RoomJoiner
+type RoomJoiner interface {
+	JoinRoomInTx(
+		ctx context.Context,
+		tx *sqlx.Tx,
+		roomId entity.RoomId,
+		userId entity.UserId,
+		liveDifficulty entity.LiveDifficulty,
+		asSpectator bool,
+	) (entity.JoinRoomResult, error)
+}
+
+type RespondRoomInvitation struct {
+	DB      Beginner
+	Repo    RespondRoomInvitationRepository
+	Joiner  RoomJoiner
+	Clocker clock.Clocker
+}
+
+// 自分宛ての Pending の招待を取得する
+func (ri *RespondRoomInvitation) getPendingInvitation(
+	ctx context.Context,
+	db Queryer,
+	invitationId entity.RoomInvitationId,
+	userId entity.UserId,
+) (*entity.RoomInvitation, error) {
+	invitation, err := ri.Repo.GetRoomInvitation(ctx, db, invitationId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &entity.ErrNotFound{}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if invitation.InviteeUserId != userId {
+		return nil, &entity.ErrPermissionDenied{}
+	}
+	if invitation.Status != entity.RoomInvitationStatusPending {
+		return nil, &entity.ErrInvitationAnswered{}
+	}
+	return invitation, nil
+}
+
+// Pending の招待の状態を更新する (先に回答された場合は entity.ErrInvitationAnswered を返す)
+func (ri *RespondRoomInvitation) answer(
+	ctx context.Context,
+	db Execer,
+	invitationId entity.RoomInvitationId,
+	status entity.RoomInvitationStatus,
+) error {
+	err := ri.Repo.UpdateRoomInvitationStatus(ctx, db, invitationId, status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &entity.ErrInvitationAnswered{}
+	}
+	return err
+}
+
+// 招待を受けてルームに参加する
+//
+// 招待の更新とルームへの参加は同じトランザクションで行い、同時に承諾・拒否した場合は一方のみ成功する
+// ルームが満員・解散済みなどで参加できなかった場合は JoinRoom の結果をそのまま返し、招待は Pending のまま残す
+// 期限切れの招待は entity.ErrInvitationExpired を返す
+func (ri *RespondRoomInvitation) AcceptRoomInvitation(
+	ctx context.Context,
+	invitationId entity.RoomInvitationId,
+	userId entity.UserId,
+	liveDifficulty entity.LiveDifficulty,
+) (entity.JoinRoomResult, error) {
+	// helper functions
+	fail := func(err error) (entity.JoinRoomResult, error) {
+		return entity.JoinRoomResultOtherErr, fmt.Errorf("AcceptRoomInvitation: %w", err)
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) (entity.JoinRoomResult, error) {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	tx, err := ri.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	invitation, err := ri.getPendingInvitation(ctx, tx, invitationId, userId)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+	if invitation.IsExpired(ri.Clocker.Now()) {
+		return failWithRollBack(tx, &entity.ErrInvitationExpired{})
+	}
+
+	// 先に招待を更新して行をロックし、同じ招待での同時の参加を直列化する
+	if err := ri.answer(ctx, tx, invitationId, entity.RoomInvitationStatusAccepted); err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	result, err := ri.Joiner.JoinRoomInTx(ctx, tx, invitation.RoomId, userId, liveDifficulty, false)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+	if result != entity.JoinRoomResultOk {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fail(fmt.Errorf("rollbacking: %w", rollbackErr))
+		}
+		return result, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+	return result, nil
+}
+
+// 招待を断る
+func (ri *RespondRoomInvitation) DeclineRoomInvitation(
+	ctx context.Context,
+	invitationId entity.RoomInvitationId,
+	userId entity.UserId,
+) error {
+	// helper functions
+	fail := func(err error) error {
+		return fmt.Errorf("DeclineRoomInvitation: %w", err)
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) error {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	tx, err := ri.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	if _, err := ri.getPendingInvitation(ctx, tx, invitationId, userId); err != nil {
+		return failWithRollBack(tx, err)
+	}
+	if err := ri.answer(ctx, tx, invitationId, entity.RoomInvitationStatusDeclined); err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+	return nil
+}
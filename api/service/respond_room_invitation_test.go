@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+// 招待 1 件のテーブルを模した moq を返す (status は Pending の場合のみ更新できる)
+func newRespondRoomInvitationRepositoryMock(
+	invitation *entity.RoomInvitation,
+) *RespondRoomInvitationRepositoryMock {
+	moq := &RespondRoomInvitationRepositoryMock{}
+	moq.GetRoomInvitationFunc = func(_ context.Context, _ Queryer, invitationId entity.RoomInvitationId) (*entity.RoomInvitation, error) {
+		if invitation == nil || invitation.Id != invitationId {
+			return nil, fmt.Errorf("GetRoomInvitation: %w", sql.ErrNoRows)
+		}
+		got := *invitation
+		return &got, nil
+	}
+	moq.UpdateRoomInvitationStatusFunc = func(_ context.Context, _ Execer, _ entity.RoomInvitationId, status entity.RoomInvitationStatus) error {
+		if invitation.Status != entity.RoomInvitationStatusPending {
+			return fmt.Errorf("UpdateRoomInvitationStatus: %w", sql.ErrNoRows)
+		}
+		invitation.Status = status
+		return nil
+	}
+	return moq
+}
+
+func TestAcceptRoomInvitation(t *testing.T) {
+	t.Parallel()
+
+	now := clock.FixedClocker{}.Now()
+
+	type want struct {
+		result entity.JoinRoomResult
+		err    error
+		joined bool
+		// commit した場合のみ招待が Accepted になる
+		commits   int
+		rollbacks int
+	}
+	tests := map[string]struct {
+		status    entity.RoomInvitationStatus
+		inviteeId entity.UserId
+		expiresAt time.Time
+		// true の場合は招待が存在しない
+		notFound   bool
+		joinResult entity.JoinRoomResult
+		joinErr    error
+		// 招待を読んだ後に別のリクエストで回答された
+		answeredConcurrently bool
+		want                 want
+	}{
+		"ok": {
+			joinResult: entity.JoinRoomResultOk,
+			want:       want{result: entity.JoinRoomResultOk, joined: true, commits: 1},
+		},
+		"ok_room_full": {
+			// 参加できなかった場合は招待を Pending のまま残す
+			joinResult: entity.JoinRoomResultRoomFull,
+			want:       want{result: entity.JoinRoomResultRoomFull, joined: true, rollbacks: 1},
+		},
+		"ng_expired": {
+			expiresAt: now,
+			want:      want{result: entity.JoinRoomResultOtherErr, err: &entity.ErrInvitationExpired{}, rollbacks: 1},
+		},
+		"ng_already_answered": {
+			status: entity.RoomInvitationStatusDeclined,
+			want:   want{result: entity.JoinRoomResultOtherErr, err: &entity.ErrInvitationAnswered{}, rollbacks: 1},
+		},
+		"ng_answered_concurrently": {
+			// 同時に承諾・拒否した場合は後から更新した方は参加しない
+			answeredConcurrently: true,
+			want:                 want{result: entity.JoinRoomResultOtherErr, err: &entity.ErrInvitationAnswered{}, rollbacks: 1},
+		},
+		"ng_other_user": {
+			inviteeId: 3,
+			want:      want{result: entity.JoinRoomResultOtherErr, err: &entity.ErrPermissionDenied{}, rollbacks: 1},
+		},
+		"ng_not_found": {
+			notFound: true,
+			want:     want{result: entity.JoinRoomResultOtherErr, err: &entity.ErrNotFound{}, rollbacks: 1},
+		},
+		"ng_already_in_room": {
+			joinErr: &entity.ErrAlreadyInRoom{RoomId: 20},
+			want:    want{result: entity.JoinRoomResultOtherErr, err: &entity.ErrAlreadyInRoom{RoomId: 20}, joined: true, rollbacks: 1},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			var invitation *entity.RoomInvitation
+			if !tt.notFound {
+				invitation = &entity.RoomInvitation{
+					Id:            1,
+					RoomId:        10,
+					InviterUserId: 1,
+					InviteeUserId: 2,
+					Status:        entity.RoomInvitationStatusPending,
+					ExpiresAt:     now.Add(time.Minute),
+				}
+				if tt.status != 0 {
+					invitation.Status = tt.status
+				}
+				if tt.inviteeId != 0 {
+					invitation.InviteeUserId = tt.inviteeId
+				}
+				if !tt.expiresAt.IsZero() {
+					invitation.ExpiresAt = tt.expiresAt
+				}
+			}
+			moq := newRespondRoomInvitationRepositoryMock(invitation)
+			if tt.answeredConcurrently {
+				get := moq.GetRoomInvitationFunc
+				moq.GetRoomInvitationFunc = func(ctx context.Context, db Queryer, invitationId entity.RoomInvitationId) (*entity.RoomInvitation, error) {
+					got, err := get(ctx, db, invitationId)
+					invitation.Status = entity.RoomInvitationStatusDeclined
+					return got, err
+				}
+			}
+			joiner := &RoomJoinerMock{}
+			joiner.JoinRoomInTxFunc = func(
+				_ context.Context,
+				_ *sqlx.Tx,
+				_ entity.RoomId,
+				_ entity.UserId,
+				_ entity.LiveDifficulty,
+				_ bool,
+			) (entity.JoinRoomResult, error) {
+				return tt.joinResult, tt.joinErr
+			}
+
+			db, count := testutil.TxDB(t)
+			s := &RespondRoomInvitation{DB: db, Repo: moq, Joiner: joiner, Clocker: clock.FixedClocker{}}
+			got, err := s.AcceptRoomInvitation(context.Background(), 1, 2, entity.LiveDifficultyNormal)
+			if tt.want.err != nil {
+				if err == nil || errors.Unwrap(err).Error() != tt.want.err.Error() {
+					t.Fatalf("want error %v, but got %v", tt.want.err, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want.result {
+				t.Errorf("want result %v, but got %v", tt.want.result, got)
+			}
+
+			calls := joiner.JoinRoomInTxCalls()
+			if (len(calls) == 1) != tt.want.joined {
+				t.Fatalf("unexpected number of joins: %d", len(calls))
+			}
+			// 招待されたルームにプレイヤーとして参加する
+			if tt.want.joined && (calls[0].RoomId != 10 || calls[0].UserId != 2 || calls[0].AsSpectator) {
+				t.Errorf("unexpected join: %+v", calls[0])
+			}
+			if n := count.Commits(); n != tt.want.commits {
+				t.Errorf("want %d commits, but got %d", tt.want.commits, n)
+			}
+			if n := count.Rollbacks(); n != tt.want.rollbacks {
+				t.Errorf("want %d rollbacks, but got %d", tt.want.rollbacks, n)
+			}
+		})
+	}
+}
+
+func TestDeclineRoomInvitation(t *testing.T) {
+	t.Parallel()
+
+	now := clock.FixedClocker{}.Now()
+
+	tests := map[string]struct {
+		status    entity.RoomInvitationStatus
+		inviteeId entity.UserId
+		expired   bool
+		wantErr   error
+	}{
+		"ok": {
+			status:    entity.RoomInvitationStatusPending,
+			inviteeId: 2,
+		},
+		"ok_expired": {
+			// 期限切れの招待も断れる
+			status:    entity.RoomInvitationStatusPending,
+			inviteeId: 2,
+			expired:   true,
+		},
+		"ng_already_accepted": {
+			status:    entity.RoomInvitationStatusAccepted,
+			inviteeId: 2,
+			wantErr:   &entity.ErrInvitationAnswered{},
+		},
+		"ng_other_user": {
+			status:    entity.RoomInvitationStatusPending,
+			inviteeId: 3,
+			wantErr:   &entity.ErrPermissionDenied{},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			invitation := &entity.RoomInvitation{
+				Id:            1,
+				RoomId:        10,
+				InviterUserId: 1,
+				InviteeUserId: tt.inviteeId,
+				Status:        tt.status,
+				ExpiresAt:     now.Add(time.Minute),
+			}
+			if tt.expired {
+				invitation.ExpiresAt = now
+			}
+			moq := newRespondRoomInvitationRepositoryMock(invitation)
+
+			db, count := testutil.TxDB(t)
+			s := &RespondRoomInvitation{DB: db, Repo: moq, Joiner: &RoomJoinerMock{}, Clocker: clock.FixedClocker{}}
+			err := s.DeclineRoomInvitation(context.Background(), 1, 2)
+			if tt.wantErr != nil {
+				if err == nil || errors.Unwrap(err).Error() != tt.wantErr.Error() {
+					t.Fatalf("want error %v, but got %v", tt.wantErr, err)
+				}
+				if invitation.Status != tt.status {
+					t.Errorf("invitation must not be updated: %v", invitation.Status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if invitation.Status != entity.RoomInvitationStatusDeclined {
+				t.Errorf("want declined, but got %v", invitation.Status)
+			}
+			if n := count.Commits(); n != 1 {
+				t.Errorf("want 1 commit, but got %d", n)
+			}
+		})
+	}
+}
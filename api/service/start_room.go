@@ -3,42 +3,50 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/config"
 	"github.com/pollenjp/gameserver-go/api/entity"
 )
 
 // TODO: convert to //go:generate when writing tests
 // go:generate go run github.com/matryer/moq -out start_room_moq_test.go . StartRoomRepository
 type StartRoomRepository interface {
+	AuditLogger
 	GetRoom(
 		ctx context.Context,
 		db Queryer,
 		roomId entity.RoomId,
 	) (*entity.Room, error)
-	UpdateRoomStatus(
+	StartRoomLive(
 		ctx context.Context,
 		db Execer,
 		roomId entity.RoomId,
-		status entity.RoomStatus,
+		startAt time.Time,
 	) error
 }
 
 type StartRoom struct {
-	DB   Beginner
-	Repo StartRoomRepository
+	DB      Beginner
+	Repo    StartRoomRepository
+	Clocker clock.Clocker
 }
 
+// ルームを LiveStart 状態にする (host user のみ実行可能)
+//
+// 全員が同時にライブを開始できるように config.LiveStartDelay 後の時刻を開始時刻として返す
 func (cr *StartRoom) StartRoom(
 	ctx context.Context,
 	roomId entity.RoomId,
 	hostUserId entity.UserId,
-) error {
+) (time.Time, error) {
 	// helper functions
-	fail := func(err error) error {
-		return err
+	fail := func(err error) (time.Time, error) {
+		return time.Time{}, err
 	}
-	failWithRollBack := func(tx *sqlx.Tx, err error) error {
+	failWithRollBack := func(tx *sqlx.Tx, err error) (time.Time, error) {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
 		}
@@ -63,7 +71,16 @@ func (cr *StartRoom) StartRoom(
 		return failWithRollBack(tx, fmt.Errorf("room status is not waiting: %v", room.Status))
 	}
 
-	if err := cr.Repo.UpdateRoomStatus(ctx, tx, roomId, entity.RoomStatusLiveStart); err != nil {
+	startAt := cr.Clocker.Now().Add(config.LiveStartDelay)
+	if err := cr.Repo.StartRoomLive(ctx, tx, roomId, startAt); err != nil {
+		return failWithRollBack(tx, err)
+	}
+	if err := recordAudit(ctx, tx, cr.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionRoomStart,
+		ActorUserId:  hostUserId,
+		TargetRoomId: roomId,
+		Detail:       fmt.Sprintf("round=%d start_at=%s", room.Round, startAt.Format(time.RFC3339)),
+	}); err != nil {
 		return failWithRollBack(tx, err)
 	}
 
@@ -71,5 +88,5 @@ func (cr *StartRoom) StartRoom(
 		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
 	}
 
-	return nil
+	return startAt, nil
 }
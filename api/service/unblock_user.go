@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// TODO: convert to //go:generate when writing tests
+type UnblockUserRepository interface {
+	DeleteUserBlock(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+		blockedUserId entity.UserId,
+	) error
+}
+
+type UnblockUser struct {
+	DB   Execer
+	Repo UnblockUserRepository
+}
+
+func (uu *UnblockUser) UnblockUser(
+	ctx context.Context,
+	userId entity.UserId,
+	blockedUserId entity.UserId,
+) error {
+	if err := uu.Repo.DeleteUserBlock(ctx, uu.DB, userId, blockedUserId); err != nil {
+		return fmt.Errorf("UnblockUser: %w", err)
+	}
+	return nil
+}
@@ -2,27 +2,61 @@ package service
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/jmoiron/sqlx"
 	"github.com/pollenjp/gameserver-go/api/entity"
 )
 
-// TODO: convert to //go:generate when writing tests
-// go:generate go run github.com/matryer/moq -out update_user_moq_test.go . UserUpdater
+//go:generate go run github.com/matryer/moq -out update_user_moq_test.go . UserUpdater
 type UserUpdater interface {
+	AuditLogger
 	UpdateUser(ctx context.Context, db Execer, newUser *entity.User) error
 }
 
 type UpdateUser struct {
-	DB   Execer
+	DB   Beginner
 	Repo UserUpdater
+	// nil の場合はキャッシュを使っていない
+	Cache SessionCacheInvalidator
 }
 
 func (ru *UpdateUser) UpdateUser(
 	ctx context.Context,
 	user *entity.User,
 ) error {
-	if err := ru.Repo.UpdateUser(ctx, ru.DB, user); err != nil {
-		return err
+	// helper functions
+	fail := func(err error) error {
+		return fmt.Errorf("UpdateUser: %w", err)
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) error {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	tx, err := ru.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	if err := ru.Repo.UpdateUser(ctx, tx, user); err != nil {
+		return failWithRollBack(tx, err)
+	}
+	if err := recordAudit(ctx, tx, ru.Repo, &entity.AuditLog{
+		Action:       entity.AuditActionUserUpdate,
+		TargetUserId: user.Id,
+		Detail:       fmt.Sprintf("name=%q leader_card_id=%d", user.Name, user.LeaderCardId),
+	}); err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+	if ru.Cache != nil {
+		ru.Cache.InvalidateUser(user.Id)
 	}
 	return nil
 }
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+type invalidatorFunc func(userId entity.UserId)
+
+func (f invalidatorFunc) InvalidateUser(userId entity.UserId) {
+	f(userId)
+}
+
+func TestUpdateUser(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		err         bool
+		commits     int
+		rollbacks   int
+		invalidated bool
+	}
+	tests := map[string]struct {
+		auditErr error
+		want     want
+	}{
+		"ok": {
+			want: want{commits: 1, invalidated: true},
+		},
+		"ng_audit": {
+			// 監査ログを記録できない場合は更新も取り消す
+			auditErr: errors.New("error from mock"),
+			want:     want{err: true, rollbacks: 1},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			db, count := testutil.TxDB(t)
+			moq := &UserUpdaterMock{}
+			moq.UpdateUserFunc = func(_ context.Context, _ Execer, _ *entity.User) error {
+				return nil
+			}
+			moq.CreateAuditLogFunc = func(_ context.Context, _ Execer, _ *entity.AuditLog) error {
+				return tt.auditErr
+			}
+			invalidated := false
+			s := &UpdateUser{
+				DB:   db,
+				Repo: moq,
+				Cache: invalidatorFunc(func(_ entity.UserId) {
+					invalidated = true
+				}),
+			}
+
+			err := s.UpdateUser(context.Background(), &entity.User{Id: 1, Name: "name"})
+			if (err != nil) != tt.want.err {
+				t.Fatalf("want error %v, but got %v", tt.want.err, err)
+			}
+			if n := count.Commits(); n != tt.want.commits {
+				t.Errorf("want %d commits, but got %d", tt.want.commits, n)
+			}
+			if n := count.Rollbacks(); n != tt.want.rollbacks {
+				t.Errorf("want %d rollbacks, but got %d", tt.want.rollbacks, n)
+			}
+			if invalidated != tt.want.invalidated {
+				t.Errorf("want invalidated %v, but got %v", tt.want.invalidated, invalidated)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+//go:generate go run github.com/matryer/moq -out user_session_moq_test.go . UserSessionRepository
+type UserSessionRepository interface {
+	IssueUserSession(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+	) (*entity.UserSession, error)
+	RevokeUserSession(
+		ctx context.Context,
+		db Execer,
+		sessionId entity.UserSessionId,
+	) error
+	RevokeAllUserSessions(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+	) error
+}
+
+// 認証時のセッションのキャッシュ
+type SessionCacheInvalidator interface {
+	InvalidateUser(userId entity.UserId)
+}
+
+type UserSession struct {
+	DB   Beginner
+	Repo UserSessionRepository
+	// nil の場合はキャッシュを使っていない
+	Cache SessionCacheInvalidator
+}
+
+// revoke でセッションを無効化した後に新しいセッションを発行する
+func (us *UserSession) reissue(
+	ctx context.Context,
+	userId entity.UserId,
+	revoke func(tx *sqlx.Tx) error,
+) (*entity.UserSession, error) {
+	// helper functions
+	fail := func(err error) (*entity.UserSession, error) {
+		return nil, err
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) (*entity.UserSession, error) {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	tx, err := us.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	if err := revoke(tx); err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	session, err := us.Repo.IssueUserSession(ctx, tx, userId)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+	if us.Cache != nil {
+		us.Cache.InvalidateUser(userId)
+	}
+	return session, nil
+}
+
+// 認証に使われたセッションを無効化して新しいトークンを発行する
+func (us *UserSession) RefreshUserToken(
+	ctx context.Context,
+	userId entity.UserId,
+	sessionId entity.UserSessionId,
+) (*entity.UserSession, error) {
+	session, err := us.reissue(ctx, userId, func(tx *sqlx.Tx) error {
+		return us.Repo.RevokeUserSession(ctx, tx, sessionId)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("RefreshUserToken: %w", err)
+	}
+	return session, nil
+}
+
+// 全てのセッションを無効化し、リクエストしたクライアント用に新しいトークンを発行する
+func (us *UserSession) RevokeAllUserSessions(
+	ctx context.Context,
+	userId entity.UserId,
+) (*entity.UserSession, error) {
+	session, err := us.reissue(ctx, userId, func(tx *sqlx.Tx) error {
+		return us.Repo.RevokeAllUserSessions(ctx, tx, userId)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("RevokeAllUserSessions: %w", err)
+	}
+	return session, nil
+}
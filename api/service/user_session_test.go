@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+)
+
+// user_session のテーブルを模した moq を返す (commit・rollback は区別しない)
+func newUserSessionRepositoryMock(
+	now time.Time,
+	sessions map[entity.UserSessionId]*entity.UserSession,
+) *UserSessionRepositoryMock {
+	var mu sync.Mutex
+	nextId := entity.UserSessionId(len(sessions) + 1)
+
+	moq := &UserSessionRepositoryMock{}
+	moq.IssueUserSessionFunc = func(_ context.Context, _ Execer, userId entity.UserId) (*entity.UserSession, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		s := &entity.UserSession{
+			Id:        nextId,
+			UserId:    userId,
+			Token:     "new",
+			IssuedAt:  now,
+			ExpiresAt: now.Add(config.SessionLifetime),
+		}
+		sessions[s.Id] = s
+		nextId++
+		return s, nil
+	}
+	moq.RevokeUserSessionFunc = func(_ context.Context, _ Execer, sessionId entity.UserSessionId) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if s, ok := sessions[sessionId]; ok && s.RevokedAt == nil {
+			s.RevokedAt = &now
+		}
+		return nil
+	}
+	moq.RevokeAllUserSessionsFunc = func(_ context.Context, _ Execer, userId entity.UserId) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, s := range sessions {
+			if s.UserId == userId && s.RevokedAt == nil {
+				s.RevokedAt = &now
+			}
+		}
+		return nil
+	}
+	return moq
+}
+
+func TestUserSession(t *testing.T) {
+	t.Parallel()
+
+	now := clock.FixedClocker{}.Now()
+
+	type want struct {
+		// 操作後に有効なセッション (新しく発行したものを除く)
+		valid   []entity.UserSessionId
+		revoked []entity.UserSessionId
+	}
+	tests := map[string]struct {
+		call func(s *UserSession) (*entity.UserSession, error)
+		want want
+	}{
+		"refresh": {
+			// 認証に使ったセッションのみを無効化する
+			call: func(s *UserSession) (*entity.UserSession, error) {
+				return s.RefreshUserToken(context.Background(), 1, 1)
+			},
+			want: want{
+				valid:   []entity.UserSessionId{2, 3},
+				revoked: []entity.UserSessionId{1},
+			},
+		},
+		"revoke_all": {
+			// 他のユーザーのセッションは無効化しない
+			call: func(s *UserSession) (*entity.UserSession, error) {
+				return s.RevokeAllUserSessions(context.Background(), 1)
+			},
+			want: want{
+				valid:   []entity.UserSessionId{3},
+				revoked: []entity.UserSessionId{1, 2},
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			sessions := map[entity.UserSessionId]*entity.UserSession{
+				1: {Id: 1, UserId: 1, ExpiresAt: now.Add(time.Hour)},
+				2: {Id: 2, UserId: 1, ExpiresAt: now.Add(time.Hour)},
+				3: {Id: 3, UserId: 2, ExpiresAt: now.Add(time.Hour)},
+			}
+			db, count := testutil.TxDB(t)
+			var invalidated []entity.UserId
+			s := &UserSession{
+				DB:   db,
+				Repo: newUserSessionRepositoryMock(now, sessions),
+				Cache: invalidatorFunc(func(userId entity.UserId) {
+					invalidated = append(invalidated, userId)
+				}),
+			}
+
+			got, err := tt.call(s)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// 新しいセッションは SessionLifetime の間だけ有効
+			if got.UserId != 1 || got.IsRevoked() {
+				t.Errorf("new session must be valid for user 1: %+v", got)
+			}
+			if got.IsExpired(now.Add(config.SessionLifetime - time.Second)) {
+				t.Errorf("new session must not expire before %v", config.SessionLifetime)
+			}
+			if !got.IsExpired(now.Add(config.SessionLifetime)) {
+				t.Errorf("new session must expire after %v", config.SessionLifetime)
+			}
+
+			for _, id := range tt.want.valid {
+				if sessions[id].IsRevoked() {
+					t.Errorf("session %d must not be revoked", id)
+				}
+			}
+			for _, id := range tt.want.revoked {
+				if !sessions[id].IsRevoked() {
+					t.Errorf("session %d must be revoked", id)
+				}
+			}
+			if n := count.Commits(); n != 1 {
+				t.Errorf("want 1 commit, but got %d", n)
+			}
+			// キャッシュに残った古いセッションで認証できないようにする
+			if len(invalidated) != 1 || invalidated[0] != 1 {
+				t.Errorf("want cache of user 1 invalidated, but got %v", invalidated)
+			}
+		})
+	}
+}
+
+func TestUserSessionRollback(t *testing.T) {
+	t.Parallel()
+
+	db, count := testutil.TxDB(t)
+	moq := newUserSessionRepositoryMock(clock.FixedClocker{}.Now(), map[entity.UserSessionId]*entity.UserSession{})
+	moq.RevokeAllUserSessionsFunc = func(_ context.Context, _ Execer, _ entity.UserId) error {
+		return errors.New("error from mock")
+	}
+	invalidated := false
+	s := &UserSession{
+		DB:   db,
+		Repo: moq,
+		Cache: invalidatorFunc(func(_ entity.UserId) {
+			invalidated = true
+		}),
+	}
+
+	// 無効化に失敗した場合は新しいトークンを発行しない
+	if _, err := s.RevokeAllUserSessions(context.Background(), 1); err == nil {
+		t.Fatal("want error, but got nil")
+	}
+	if n := len(moq.IssueUserSessionCalls()); n != 0 {
+		t.Errorf("want no sessions issued, but got %d", n)
+	}
+	if n := count.Rollbacks(); n != 1 {
+		t.Errorf("want 1 rollback, but got %d", n)
+	}
+	if invalidated {
+		t.Error("cache must not be invalidated")
+	}
+}
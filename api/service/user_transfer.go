@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"golang.org/x/crypto/bcrypt"
+)
+
+//go:generate go run github.com/matryer/moq -out user_transfer_moq_test.go . UserTransferRepository
+type UserTransferRepository interface {
+	IssueUserTransfer(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+		passwordHash string,
+		expiresAt time.Time,
+	) (*entity.UserTransfer, error)
+	GetUserTransfer(
+		ctx context.Context,
+		db Queryer,
+		transferId entity.TransferIdType,
+	) (*entity.UserTransfer, error)
+	UpdateUserTransferFailure(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+		failedCount int,
+		lockedUntil *time.Time,
+	) error
+	DeleteUserTransfer(
+		ctx context.Context,
+		db Execer,
+		transferId entity.TransferIdType,
+	) error
+	RevokeAllUserSessions(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+	) error
+	IssueUserSession(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+	) (*entity.UserSession, error)
+	RotateUserToken(
+		ctx context.Context,
+		db Execer,
+		userId entity.UserId,
+	) error
+}
+
+type UserTransfer struct {
+	DB      Beginner
+	Repo    UserTransferRepository
+	Clocker clock.Clocker
+	// nil の場合はキャッシュを使っていない
+	Cache SessionCacheInvalidator
+}
+
+// 引き継ぎコードを発行する (発行済みのコードは無効になる)
+func (ut *UserTransfer) IssueUserTransfer(
+	ctx context.Context,
+	userId entity.UserId,
+	password string,
+) (*entity.UserTransfer, error) {
+	// helper functions
+	fail := func(err error) (*entity.UserTransfer, error) {
+		return nil, fmt.Errorf("IssueUserTransfer: %w", err)
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) (*entity.UserTransfer, error) {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return fail(err)
+	}
+
+	tx, err := ut.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	expiresAt := ut.Clocker.Now().Add(config.TransferLifetime)
+	transfer, err := ut.Repo.IssueUserTransfer(ctx, tx, userId, string(passwordHash), expiresAt)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+	return transfer, nil
+}
+
+// 引き継ぎコードとパスワードで別の端末にアカウントを引き継ぐ
+//
+// - 成功すると既存のトークンを全て無効化して新しいトークンを発行し、引き継ぎコードは削除する
+// - コードが存在しない・期限切れ・パスワードが違う場合は entity.ErrUnauthorized を返す
+// - ロック中の場合は entity.ErrTooManyRequests を返す
+func (ut *UserTransfer) RedeemUserTransfer(
+	ctx context.Context,
+	transferId entity.TransferIdType,
+	password string,
+) (*entity.UserSession, error) {
+	// helper functions
+	fail := func(err error) (*entity.UserSession, error) {
+		return nil, fmt.Errorf("RedeemUserTransfer: %w", err)
+	}
+	failWithRollBack := func(tx *sqlx.Tx, err error) (*entity.UserSession, error) {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			err = fmt.Errorf("rollbacking: %w: %v", rollbackErr, err)
+		}
+		return fail(err)
+	}
+
+	now := ut.Clocker.Now()
+
+	tx, err := ut.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fail(fmt.Errorf("BeginTxx: %w", err))
+	}
+
+	// 失敗回数を確認してから更新するまで行をロックする (並列に試行してロックを回避させない)
+	transfer, err := ut.Repo.GetUserTransfer(ctx, tx, transferId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return failWithRollBack(tx, &entity.ErrUnauthorized{})
+	}
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+	if transfer.IsLocked(now) {
+		return failWithRollBack(tx, &entity.ErrTooManyRequests{})
+	}
+	if transfer.IsExpired(now) {
+		return failWithRollBack(tx, &entity.ErrUnauthorized{})
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(transfer.PasswordHash), []byte(password)); err != nil {
+		// 失敗回数は記録して commit する
+		failedCount := transfer.FailedCount + 1
+		var lockedUntil *time.Time
+		var rspErr error = &entity.ErrUnauthorized{}
+		if failedCount >= config.TransferMaxFailedAttempts {
+			t := now.Add(config.TransferLockoutDuration)
+			lockedUntil = &t
+			failedCount = 0
+			rspErr = &entity.ErrTooManyRequests{}
+		}
+		if err := ut.Repo.UpdateUserTransferFailure(ctx, tx, transfer.UserId, failedCount, lockedUntil); err != nil {
+			return failWithRollBack(tx, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+		}
+		return fail(rspErr)
+	}
+
+	// 同じコードでの同時リクエストは 1 つだけが成功する
+	if err := ut.Repo.DeleteUserTransfer(ctx, tx, transferId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return failWithRollBack(tx, &entity.ErrUnauthorized{})
+		}
+		return failWithRollBack(tx, err)
+	}
+	if err := ut.Repo.RevokeAllUserSessions(ctx, tx, transfer.UserId); err != nil {
+		return failWithRollBack(tx, err)
+	}
+	// 引き継ぎ元の端末に残った平文の古いトークンも使えなくする
+	if err := ut.Repo.RotateUserToken(ctx, tx, transfer.UserId); err != nil {
+		return failWithRollBack(tx, err)
+	}
+	session, err := ut.Repo.IssueUserSession(ctx, tx, transfer.UserId)
+	if err != nil {
+		return failWithRollBack(tx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return failWithRollBack(tx, fmt.Errorf("committing: %w", err))
+	}
+	if ut.Cache != nil {
+		ut.Cache.InvalidateUser(transfer.UserId)
+	}
+	return session, nil
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/config"
+	"github.com/pollenjp/gameserver-go/api/entity"
+	"github.com/pollenjp/gameserver-go/api/testutil"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestIssueUserTransfer(t *testing.T) {
+	t.Parallel()
+
+	db, count := testutil.TxDB(t)
+	now := clock.FixedClocker{}.Now()
+
+	moq := &UserTransferRepositoryMock{}
+	moq.IssueUserTransferFunc = func(
+		_ context.Context,
+		_ Execer,
+		userId entity.UserId,
+		passwordHash string,
+		expiresAt time.Time,
+	) (*entity.UserTransfer, error) {
+		return &entity.UserTransfer{
+			UserId:       userId,
+			TransferId:   "code",
+			PasswordHash: passwordHash,
+			ExpiresAt:    expiresAt,
+		}, nil
+	}
+
+	s := &UserTransfer{DB: db, Repo: moq, Clocker: clock.FixedClocker{}}
+	got, err := s.IssueUserTransfer(context.Background(), 1, "password")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.ExpiresAt.Equal(now.Add(config.TransferLifetime)) {
+		t.Errorf("want expires_at %v, but got %v", now.Add(config.TransferLifetime), got.ExpiresAt)
+	}
+	// パスワードは平文で保存しない
+	if got.PasswordHash == "password" {
+		t.Errorf("password is stored as plain text")
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(got.PasswordHash), []byte("password")); err != nil {
+		t.Errorf("password hash does not match: %v", err)
+	}
+	if n := count.Commits(); n != 1 {
+		t.Errorf("want 1 commit, but got %d", n)
+	}
+}
+
+func TestRedeemUserTransfer(t *testing.T) {
+	t.Parallel()
+
+	now := clock.FixedClocker{}.Now()
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lockedUntil := now.Add(time.Second)
+	session := &entity.UserSession{UserId: 1, Token: "token"}
+
+	type failure struct {
+		failedCount int
+		locked      bool
+	}
+	type want struct {
+		session *entity.UserSession
+		err     error
+		// nil の場合は失敗回数を更新しない
+		failure *failure
+		commits int
+	}
+	tests := map[string]struct {
+		// nil の場合はコードが存在しない
+		transfer *entity.UserTransfer
+		password string
+		// DeleteUserTransfer が返すエラー
+		deleteErr error
+		want      want
+	}{
+		"ok": {
+			transfer: &entity.UserTransfer{UserId: 1, PasswordHash: string(hash), ExpiresAt: now.Add(time.Hour)},
+			password: "password",
+			want:     want{session: session, commits: 1},
+		},
+		"ok_lock_expired": {
+			transfer: &entity.UserTransfer{UserId: 1, PasswordHash: string(hash), ExpiresAt: now.Add(time.Hour), LockedUntil: &now},
+			password: "password",
+			want:     want{session: session, commits: 1},
+		},
+		"ng_not_found": {
+			password: "password",
+			want:     want{err: &entity.ErrUnauthorized{}},
+		},
+		"ng_expired": {
+			transfer: &entity.UserTransfer{UserId: 1, PasswordHash: string(hash), ExpiresAt: now},
+			password: "password",
+			want:     want{err: &entity.ErrUnauthorized{}},
+		},
+		"ng_locked": {
+			// ロック中は正しいパスワードでも引き継げない
+			transfer: &entity.UserTransfer{UserId: 1, PasswordHash: string(hash), ExpiresAt: now.Add(time.Hour), LockedUntil: &lockedUntil},
+			password: "password",
+			want:     want{err: &entity.ErrTooManyRequests{}},
+		},
+		"ng_wrong_password": {
+			transfer: &entity.UserTransfer{UserId: 1, PasswordHash: string(hash), ExpiresAt: now.Add(time.Hour), FailedCount: 1},
+			password: "wrong",
+			want: want{
+				err:     &entity.ErrUnauthorized{},
+				failure: &failure{failedCount: 2},
+				commits: 1,
+			},
+		},
+		"ng_lockout": {
+			transfer: &entity.UserTransfer{
+				UserId:       1,
+				PasswordHash: string(hash),
+				ExpiresAt:    now.Add(time.Hour),
+				FailedCount:  config.TransferMaxFailedAttempts - 1,
+			},
+			password: "wrong",
+			want: want{
+				err:     &entity.ErrTooManyRequests{},
+				failure: &failure{failedCount: 0, locked: true},
+				commits: 1,
+			},
+		},
+		"ng_already_redeemed": {
+			// 同じコードでの同時リクエストの片方
+			transfer:  &entity.UserTransfer{UserId: 1, PasswordHash: string(hash), ExpiresAt: now.Add(time.Hour)},
+			password:  "password",
+			deleteErr: fmt.Errorf("DeleteUserTransfer: %w", sql.ErrNoRows),
+			want:      want{err: &entity.ErrUnauthorized{}},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			db, count := testutil.TxDB(t)
+			moq := &UserTransferRepositoryMock{}
+			moq.GetUserTransferFunc = func(_ context.Context, _ Queryer, _ entity.TransferIdType) (*entity.UserTransfer, error) {
+				if tt.transfer == nil {
+					return nil, fmt.Errorf("GetUserTransfer: %w", sql.ErrNoRows)
+				}
+				return tt.transfer, nil
+			}
+			moq.UpdateUserTransferFailureFunc = func(_ context.Context, _ Execer, _ entity.UserId, _ int, _ *time.Time) error {
+				return nil
+			}
+			moq.DeleteUserTransferFunc = func(_ context.Context, _ Execer, _ entity.TransferIdType) error {
+				return tt.deleteErr
+			}
+			moq.RevokeAllUserSessionsFunc = func(_ context.Context, _ Execer, _ entity.UserId) error {
+				return nil
+			}
+			moq.IssueUserSessionFunc = func(_ context.Context, _ Execer, _ entity.UserId) (*entity.UserSession, error) {
+				return session, nil
+			}
+			moq.RotateUserTokenFunc = func(_ context.Context, _ Execer, _ entity.UserId) error {
+				return nil
+			}
+
+			s := &UserTransfer{DB: db, Repo: moq, Clocker: clock.FixedClocker{}}
+			got, err := s.RedeemUserTransfer(context.Background(), "code", tt.password)
+			if tt.want.err != nil {
+				if err == nil || errors.Unwrap(err).Error() != tt.want.err.Error() {
+					t.Fatalf("want error %v, but got %v", tt.want.err, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(got, tt.want.session); len(d) != 0 {
+				t.Errorf("differs: (-got +want)\n%s", d)
+			}
+
+			calls := moq.UpdateUserTransferFailureCalls()
+			switch {
+			case tt.want.failure == nil && len(calls) != 0:
+				t.Errorf("want no failure updates, but got %d", len(calls))
+			case tt.want.failure != nil && len(calls) != 1:
+				t.Errorf("want 1 failure update, but got %d", len(calls))
+			case tt.want.failure != nil:
+				if calls[0].FailedCount != tt.want.failure.failedCount {
+					t.Errorf("want failed count %d, but got %d", tt.want.failure.failedCount, calls[0].FailedCount)
+				}
+				if locked := calls[0].LockedUntil != nil; locked != tt.want.failure.locked {
+					t.Errorf("want locked %v, but got %v", tt.want.failure.locked, locked)
+				}
+				if tt.want.failure.locked && !calls[0].LockedUntil.Equal(now.Add(config.TransferLockoutDuration)) {
+					t.Errorf("want locked until %v, but got %v", now.Add(config.TransferLockoutDuration), *calls[0].LockedUntil)
+				}
+			}
+			if n := count.Commits(); n != tt.want.commits {
+				t.Errorf("want %d commits, but got %d", tt.want.commits, n)
+			}
+			// 引き継ぎに成功した場合のみ既存のトークンを無効化する
+			if n := len(moq.RevokeAllUserSessionsCalls()); (n == 1) != (tt.want.session != nil) {
+				t.Errorf("unexpected number of revocations: %d", n)
+			}
+			// 引き継ぎ元の端末の平文のトークンが移行されて再び使えるようにならない
+			rotations := moq.RotateUserTokenCalls()
+			if (len(rotations) == 1) != (tt.want.session != nil) {
+				t.Errorf("unexpected number of token rotations: %d", len(rotations))
+			}
+			if len(rotations) == 1 && rotations[0].UserId != tt.transfer.UserId {
+				t.Errorf("want token of user %d rotated, but got %d", tt.transfer.UserId, rotations[0].UserId)
+			}
+		})
+	}
+}
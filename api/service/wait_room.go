@@ -15,6 +15,7 @@ type WaitingRoomUser struct {
 	LeaderCardId     entity.LeaderCardIdIDType `json:"leader_card_id" db:"leader_card_id"`
 	SelectDifficulty entity.LiveDifficulty     `json:"select_difficulty" db:"select_difficulty"`
 	IsHost           bool                      `json:"is_host" db:"is_host"`
+	IsSpectator      bool                      `json:"is_spectator" db:"is_spectator"`
 }
 
 // handler への返り値に利用.
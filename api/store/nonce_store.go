@@ -0,0 +1,58 @@
+package store
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+)
+
+// 使用済みの nonce を TTL の間記録し、再利用を拒否する
+//
+// サーバー毎のメモリに保持するため、複数台構成では別のサーバーへの再送は検出できない
+type NonceStore struct {
+	Clocker clock.Clocker
+	TTL     time.Duration
+
+	mu        sync.Mutex
+	expiresAt map[string]time.Time
+	lastPurge time.Time
+}
+
+func NewNonceStore(c clock.Clocker, ttl time.Duration) *NonceStore {
+	return &NonceStore{
+		Clocker:   c,
+		TTL:       ttl,
+		expiresAt: make(map[string]time.Time),
+	}
+}
+
+// 初めて使われた nonce の場合のみ true を返す
+func (s *NonceStore) Use(nonce string) bool {
+	now := s.Clocker.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.purge(now)
+
+	if expiresAt, ok := s.expiresAt[nonce]; ok && now.Before(expiresAt) {
+		return false
+	}
+	s.expiresAt[nonce] = now.Add(s.TTL)
+	return true
+}
+
+// 期限切れの nonce を破棄する (s.mu をロックした状態で呼び出す)
+func (s *NonceStore) purge(now time.Time) {
+	if now.Sub(s.lastPurge) < time.Minute {
+		return
+	}
+	s.lastPurge = now
+
+	for nonce, expiresAt := range s.expiresAt {
+		if !now.Before(expiresAt) {
+			delete(s.expiresAt, nonce)
+		}
+	}
+}
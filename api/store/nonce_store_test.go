@@ -0,0 +1,59 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+)
+
+func TestNonceStore(t *testing.T) {
+	t.Parallel()
+
+	type use struct {
+		nonce string
+		// 前の呼び出しからの経過時間
+		after  time.Duration
+		wantOk bool
+	}
+
+	tests := map[string]struct {
+		uses []use
+	}{
+		"ng_reused": {
+			uses: []use{
+				{nonce: "a", wantOk: true},
+				{nonce: "a", after: 59 * time.Second, wantOk: false},
+			},
+		},
+		"ok_nonces_are_independent": {
+			uses: []use{
+				{nonce: "a", wantOk: true},
+				{nonce: "b", wantOk: true},
+			},
+		},
+		"ok_expired": {
+			uses: []use{
+				{nonce: "a", wantOk: true},
+				{nonce: "a", after: time.Minute, wantOk: true},
+				{nonce: "a", after: time.Second, wantOk: false},
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			c := &stepClocker{now: clock.FixedClocker{}.Now()}
+			s := NewNonceStore(c, time.Minute)
+			for i, u := range tt.uses {
+				c.now = c.now.Add(u.after)
+				if got := s.Use(u.nonce); got != u.wantOk {
+					t.Errorf("use %d (%s): want %v, but got %v", i, u.nonce, u.wantOk, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+const (
+	// 最後の報告からこの時間が経過したルームの途中経過は破棄する
+	progressRetention = 10 * time.Minute
+)
+
+type roomProgress struct {
+	round      int
+	updatedAt  time.Time
+	progresses map[entity.UserId]*entity.LiveProgress
+}
+
+// ライブの途中経過をルーム毎にメモリ上に保持する
+//
+// 途中経過は永続化する必要がないため DB には格納しない
+type ProgressStore struct {
+	Clocker clock.Clocker
+
+	mu        sync.RWMutex
+	rooms     map[entity.RoomId]*roomProgress
+	lastPurge time.Time
+}
+
+func NewProgressStore(c clock.Clocker) *ProgressStore {
+	return &ProgressStore{
+		Clocker: c,
+		rooms:   make(map[entity.RoomId]*roomProgress),
+	}
+}
+
+// ユーザーの最新の途中経過を上書きする
+//
+// 新しいラウンドの報告を受け取った場合は前のラウンドの途中経過を破棄する
+func (s *ProgressStore) SetProgress(
+	roomId entity.RoomId,
+	round int,
+	progress *entity.LiveProgress,
+) {
+	now := s.Clocker.Now()
+	progress.ReportedAt = now
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.purge(now)
+
+	rp, ok := s.rooms[roomId]
+	if !ok || rp.round < round {
+		rp = &roomProgress{
+			round:      round,
+			progresses: make(map[entity.UserId]*entity.LiveProgress),
+		}
+		s.rooms[roomId] = rp
+	}
+	if rp.round > round {
+		// 前のラウンドの遅れて届いた報告は無視する
+		return
+	}
+
+	rp.updatedAt = now
+	rp.progresses[progress.UserId] = progress
+}
+
+// ラウンドの全員の最新の途中経過をスコアの降順で返す
+func (s *ProgressStore) GetProgressList(
+	roomId entity.RoomId,
+	round int,
+) []*entity.LiveProgress {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	rp, ok := s.rooms[roomId]
+	if !ok || rp.round != round {
+		return []*entity.LiveProgress{}
+	}
+
+	progressList := make([]*entity.LiveProgress, 0, len(rp.progresses))
+	for _, p := range rp.progresses {
+		copied := *p
+		progressList = append(progressList, &copied)
+	}
+	sort.Slice(progressList, func(i, j int) bool {
+		if progressList[i].Score != progressList[j].Score {
+			return progressList[i].Score > progressList[j].Score
+		}
+		return progressList[i].UserId < progressList[j].UserId
+	})
+	return progressList
+}
+
+// 古いルームの途中経過を破棄する (s.mu をロックした状態で呼び出す)
+func (s *ProgressStore) purge(now time.Time) {
+	if now.Sub(s.lastPurge) < time.Minute {
+		return
+	}
+	s.lastPurge = now
+
+	for roomId, rp := range s.rooms {
+		if now.Sub(rp.updatedAt) > progressRetention {
+			delete(s.rooms, roomId)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+func TestProgressStore(t *testing.T) {
+	t.Parallel()
+
+	type report struct {
+		round  int
+		userId entity.UserId
+		score  int
+		combo  int
+	}
+
+	c := clock.FixedClocker{}
+	roomId := entity.RoomId(1)
+
+	tests := map[string]struct {
+		reports []report
+		round   int
+		want    []*entity.LiveProgress
+	}{
+		"ok_latest_progress_sorted_by_score": {
+			reports: []report{
+				{round: 1, userId: 1, score: 100, combo: 10},
+				{round: 1, userId: 2, score: 300, combo: 30},
+				{round: 1, userId: 1, score: 500, combo: 50},
+			},
+			round: 1,
+			want: []*entity.LiveProgress{
+				{UserId: 1, Score: 500, Combo: 50, ReportedAt: c.Now()},
+				{UserId: 2, Score: 300, Combo: 30, ReportedAt: c.Now()},
+			},
+		},
+		"ok_new_round_discards_previous_round": {
+			reports: []report{
+				{round: 1, userId: 1, score: 100, combo: 10},
+				{round: 2, userId: 2, score: 200, combo: 20},
+				{round: 1, userId: 3, score: 300, combo: 30},
+			},
+			round: 2,
+			want: []*entity.LiveProgress{
+				{UserId: 2, Score: 200, Combo: 20, ReportedAt: c.Now()},
+			},
+		},
+		"ok_other_round_is_empty": {
+			reports: []report{
+				{round: 1, userId: 1, score: 100, combo: 10},
+			},
+			round: 2,
+			want:  []*entity.LiveProgress{},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			sut := NewProgressStore(c)
+			for _, r := range tt.reports {
+				sut.SetProgress(roomId, r.round, &entity.LiveProgress{
+					UserId: r.userId,
+					Score:  r.score,
+					Combo:  r.combo,
+				})
+			}
+
+			got := sut.GetProgressList(roomId, tt.round)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("progress list mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+)
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// トークンバケットによるレートリミット
+//
+// キー毎に Burst 回まで連続で許可し、Interval 毎に 1 回分回復する
+type RateLimiter struct {
+	Clocker  clock.Clocker
+	Interval time.Duration
+	Burst    int
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastPurge time.Time
+}
+
+func NewRateLimiter(c clock.Clocker, interval time.Duration, burst int) *RateLimiter {
+	return &RateLimiter{
+		Clocker:  c,
+		Interval: interval,
+		Burst:    burst,
+		buckets:  make(map[string]*tokenBucket),
+	}
+}
+
+// 許可しない場合は次に許可されるまでの時間を返す
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	now := l.Clocker.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.purge(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{
+			tokens:    float64(l.Burst),
+			updatedAt: now,
+		}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.updatedAt = now
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) * float64(l.Interval))
+	}
+	b.tokens--
+	return true, 0
+}
+
+func (l *RateLimiter) refill(b *tokenBucket, now time.Time) float64 {
+	tokens := b.tokens + float64(now.Sub(b.updatedAt))/float64(l.Interval)
+	if tokens > float64(l.Burst) {
+		return float64(l.Burst)
+	}
+	return tokens
+}
+
+// 満タンまで回復したバケットは新規作成と同じなので破棄する (l.mu をロックした状態で呼び出す)
+func (l *RateLimiter) purge(now time.Time) {
+	if now.Sub(l.lastPurge) < time.Minute {
+		return
+	}
+	l.lastPurge = now
+
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= float64(l.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
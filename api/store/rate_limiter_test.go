@@ -0,0 +1,84 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	type req struct {
+		key string
+		// 前のリクエストからの経過時間
+		after          time.Duration
+		wantOk         bool
+		wantRetryAfter time.Duration
+	}
+
+	tests := map[string]struct {
+		interval time.Duration
+		burst    int
+		reqs     []req
+	}{
+		"ok_burst_then_limited": {
+			interval: time.Second,
+			burst:    2,
+			reqs: []req{
+				{key: "a", wantOk: true},
+				{key: "a", wantOk: true},
+				{key: "a", wantOk: false, wantRetryAfter: time.Second},
+			},
+		},
+		"ok_refill": {
+			interval: time.Second,
+			burst:    1,
+			reqs: []req{
+				{key: "a", wantOk: true},
+				{key: "a", after: 400 * time.Millisecond, wantOk: false, wantRetryAfter: 600 * time.Millisecond},
+				{key: "a", after: 600 * time.Millisecond, wantOk: true},
+			},
+		},
+		"ok_refill_up_to_burst": {
+			interval: time.Second,
+			burst:    2,
+			reqs: []req{
+				{key: "a", wantOk: true},
+				{key: "a", after: time.Hour, wantOk: true},
+				{key: "a", wantOk: true},
+				{key: "a", wantOk: false, wantRetryAfter: time.Second},
+			},
+		},
+		"ok_keys_are_independent": {
+			interval: time.Second,
+			burst:    1,
+			reqs: []req{
+				{key: "a", wantOk: true},
+				{key: "b", wantOk: true},
+				{key: "a", wantOk: false, wantRetryAfter: time.Second},
+			},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			c := &stepClocker{now: clock.FixedClocker{}.Now()}
+			sut := NewRateLimiter(c, tt.interval, tt.burst)
+			for i, r := range tt.reqs {
+				c.now = c.now.Add(r.after)
+				ok, retryAfter := sut.Allow(r.key)
+				if ok != r.wantOk {
+					t.Errorf("reqs[%d]: ok is not match: want %v, got %v", i, r.wantOk, ok)
+				}
+				if retryAfter != r.wantRetryAfter {
+					t.Errorf("reqs[%d]: retry after is not match: want %v, got %v", i, r.wantRetryAfter, retryAfter)
+				}
+			}
+		})
+	}
+}
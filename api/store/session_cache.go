@@ -0,0 +1,154 @@
+package store
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// メモリ上に平文のトークンを残さないようにハッシュをキーにする
+type sessionCacheKey [sha256.Size]byte
+
+type sessionCacheEntry struct {
+	key      sessionCacheKey
+	session  entity.UserSession
+	cachedAt time.Time
+}
+
+type SessionCacheStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	Size   int    `json:"size"`
+}
+
+// トークンから引いたセッションを TTL の間だけメモリ上に保持する (LRU)
+//
+// 無効化は同じプロセス内でのみ反映されるため、
+// 複数台構成では他のサーバーで無効化されたトークンが最大 TTL の間は有効なままになる
+type SessionCache struct {
+	Clocker clock.Clocker
+	TTL     time.Duration
+	// 保持するセッションの最大数
+	Capacity int
+
+	mu      sync.Mutex
+	entries map[sessionCacheKey]*list.Element
+	// 先頭ほど最近使われたエントリ
+	lru *list.List
+	// InvalidateUser の度に増やし、それ以前に DB から読んだセッションをキャッシュしないようにする
+	generation uint64
+	hits       atomic.Uint64
+	misses     atomic.Uint64
+}
+
+func NewSessionCache(c clock.Clocker, ttl time.Duration, capacity int) *SessionCache {
+	return &SessionCache{
+		Clocker:  c,
+		TTL:      ttl,
+		Capacity: capacity,
+		entries:  make(map[sessionCacheKey]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+func (s *SessionCache) Get(token entity.UserTokenType) (*entity.UserSession, bool) {
+	key := sha256.Sum256([]byte(token))
+	now := s.Clocker.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		s.misses.Add(1)
+		return nil, false
+	}
+	entry := elem.Value.(*sessionCacheEntry)
+	if now.Sub(entry.cachedAt) >= s.TTL {
+		s.remove(elem)
+		s.misses.Add(1)
+		return nil, false
+	}
+	s.lru.MoveToFront(elem)
+	s.hits.Add(1)
+
+	session := entry.session
+	session.Token = token
+	return &session, true
+}
+
+// DB からセッションを読む前に取得し、Set に渡す
+func (s *SessionCache) Generation() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.generation
+}
+
+// generation を取得してから InvalidateUser が呼ばれた場合は、無効化前のセッションの可能性があるため保持しない
+func (s *SessionCache) Set(token entity.UserTokenType, session *entity.UserSession, generation uint64) {
+	if s.Capacity <= 0 {
+		return
+	}
+	key := sha256.Sum256([]byte(token))
+	entry := &sessionCacheEntry{
+		key:      key,
+		session:  *session,
+		cachedAt: s.Clocker.Now(),
+	}
+	entry.session.Token = ""
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if generation != s.generation {
+		return
+	}
+	if elem, ok := s.entries[key]; ok {
+		elem.Value = entry
+		s.lru.MoveToFront(elem)
+		return
+	}
+	for s.lru.Len() >= s.Capacity {
+		s.remove(s.lru.Back())
+	}
+	s.entries[key] = s.lru.PushFront(entry)
+}
+
+// ユーザーの全てのセッションをキャッシュから削除する
+func (s *SessionCache) InvalidateUser(userId entity.UserId) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.generation++
+
+	for elem := s.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if elem.Value.(*sessionCacheEntry).session.UserId == userId {
+			s.remove(elem)
+		}
+		elem = next
+	}
+}
+
+func (s *SessionCache) Stats() SessionCacheStats {
+	s.mu.Lock()
+	size := s.lru.Len()
+	s.mu.Unlock()
+
+	return SessionCacheStats{
+		Hits:   s.hits.Load(),
+		Misses: s.misses.Load(),
+		Size:   size,
+	}
+}
+
+// s.mu をロックした状態で呼び出す
+func (s *SessionCache) remove(elem *list.Element) {
+	s.lru.Remove(elem)
+	delete(s.entries, elem.Value.(*sessionCacheEntry).key)
+}
@@ -0,0 +1,154 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pollenjp/gameserver-go/api/clock"
+	"github.com/pollenjp/gameserver-go/api/entity"
+)
+
+// テスト中に時刻を進められる Clocker
+type stepClocker struct {
+	now time.Time
+}
+
+func (sc *stepClocker) Now() time.Time {
+	return sc.now
+}
+
+func TestSessionCache(t *testing.T) {
+	t.Parallel()
+
+	type op struct {
+		// "begin", "set", "get", "invalidate", "advance"
+		kind    string
+		token   entity.UserTokenType
+		userId  entity.UserId
+		advance time.Duration
+		// set の場合に直前の begin で取得した世代を使う
+		stale bool
+		// get の場合に期待する結果
+		wantHit bool
+	}
+
+	tests := map[string]struct {
+		capacity  int
+		ops       []op
+		wantStats SessionCacheStats
+	}{
+		"ok_hit_and_miss": {
+			capacity: 10,
+			ops: []op{
+				{kind: "get", token: "a", wantHit: false},
+				{kind: "set", token: "a", userId: 1},
+				{kind: "get", token: "a", wantHit: true},
+				{kind: "get", token: "b", wantHit: false},
+			},
+			wantStats: SessionCacheStats{Hits: 1, Misses: 2, Size: 1},
+		},
+		"ok_ttl_expired": {
+			capacity: 10,
+			ops: []op{
+				{kind: "set", token: "a", userId: 1},
+				{kind: "advance", advance: 29 * time.Second},
+				{kind: "get", token: "a", wantHit: true},
+				{kind: "advance", advance: time.Second},
+				{kind: "get", token: "a", wantHit: false},
+			},
+			wantStats: SessionCacheStats{Hits: 1, Misses: 1, Size: 0},
+		},
+		"ok_evict_least_recently_used": {
+			capacity: 2,
+			ops: []op{
+				{kind: "set", token: "a", userId: 1},
+				{kind: "set", token: "b", userId: 2},
+				{kind: "get", token: "a", wantHit: true},
+				{kind: "set", token: "c", userId: 3},
+				{kind: "get", token: "b", wantHit: false},
+				{kind: "get", token: "a", wantHit: true},
+				{kind: "get", token: "c", wantHit: true},
+			},
+			wantStats: SessionCacheStats{Hits: 3, Misses: 1, Size: 2},
+		},
+		"ok_invalidate_user": {
+			capacity: 10,
+			ops: []op{
+				{kind: "set", token: "a", userId: 1},
+				{kind: "set", token: "b", userId: 1},
+				{kind: "set", token: "c", userId: 2},
+				{kind: "invalidate", userId: 1},
+				{kind: "get", token: "a", wantHit: false},
+				{kind: "get", token: "b", wantHit: false},
+				{kind: "get", token: "c", wantHit: true},
+			},
+			wantStats: SessionCacheStats{Hits: 1, Misses: 2, Size: 1},
+		},
+		"ok_skip_set_after_invalidate": {
+			// DB から読んでいる間に無効化されたセッションはキャッシュしない
+			capacity: 10,
+			ops: []op{
+				{kind: "begin"},
+				{kind: "invalidate", userId: 1},
+				{kind: "set", token: "a", userId: 1, stale: true},
+				{kind: "get", token: "a", wantHit: false},
+				{kind: "set", token: "a", userId: 1},
+				{kind: "get", token: "a", wantHit: true},
+			},
+			wantStats: SessionCacheStats{Hits: 1, Misses: 1, Size: 1},
+		},
+		"ok_disabled": {
+			capacity: 0,
+			ops: []op{
+				{kind: "set", token: "a", userId: 1},
+				{kind: "get", token: "a", wantHit: false},
+			},
+			wantStats: SessionCacheStats{Hits: 0, Misses: 1, Size: 0},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			c := &stepClocker{now: clock.FixedClocker{}.Now()}
+			sut := NewSessionCache(c, 30*time.Second, tt.capacity)
+			var begun uint64
+			for i, o := range tt.ops {
+				switch o.kind {
+				case "begin":
+					begun = sut.Generation()
+				case "set":
+					generation := sut.Generation()
+					if o.stale {
+						generation = begun
+					}
+					sut.Set(o.token, &entity.UserSession{
+						Id:        entity.UserSessionId(i),
+						UserId:    o.userId,
+						Token:     o.token,
+						ExpiresAt: c.Now().Add(time.Hour),
+					}, generation)
+				case "get":
+					session, ok := sut.Get(o.token)
+					if ok != o.wantHit {
+						t.Fatalf("ops[%d]: hit is not match: want %v, got %v", i, o.wantHit, ok)
+					}
+					if ok && session.Token != o.token {
+						t.Errorf("ops[%d]: token is not match: want %s, got %s", i, o.token, session.Token)
+					}
+				case "invalidate":
+					sut.InvalidateUser(o.userId)
+				case "advance":
+					c.now = c.now.Add(o.advance)
+				}
+			}
+
+			if diff := cmp.Diff(tt.wantStats, sut.Stats()); diff != "" {
+				t.Errorf("stats mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// TxDB で commit・rollback されたトランザクションの数
+type TxCount struct {
+	mu        sync.Mutex
+	commits   int
+	rollbacks int
+}
+
+func (c *TxCount) Commits() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.commits
+}
+
+func (c *TxCount) Rollbacks() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rollbacks
+}
+
+// トランザクションの開始・commit・rollback のみができる DB を返す (クエリは実行できない)
+//
+// Repo を moq に差し替えた service のテストで、BeginTxx が返す *sqlx.Tx を作るために使う
+func TxDB(t *testing.T) (*sqlx.DB, *TxCount) {
+	t.Helper()
+
+	count := &TxCount{}
+	db := sqlx.NewDb(sql.OpenDB(&txConnector{count: count}), "mysql")
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return db, count
+}
+
+var errTxDBQuery = errors.New("testutil.TxDB does not support queries")
+
+type txConnector struct {
+	count *TxCount
+}
+
+func (c *txConnector) Connect(context.Context) (driver.Conn, error) {
+	return &txConn{count: c.count}, nil
+}
+
+func (c *txConnector) Driver() driver.Driver {
+	return txDriver{}
+}
+
+type txDriver struct{}
+
+func (txDriver) Open(string) (driver.Conn, error) {
+	return nil, errTxDBQuery
+}
+
+type txConn struct {
+	count *TxCount
+}
+
+func (c *txConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errTxDBQuery
+}
+
+func (c *txConn) Close() error {
+	return nil
+}
+
+func (c *txConn) Begin() (driver.Tx, error) {
+	return &tx{count: c.count}, nil
+}
+
+type tx struct {
+	count *TxCount
+}
+
+func (t *tx) Commit() error {
+	t.count.mu.Lock()
+	defer t.count.mu.Unlock()
+	t.count.commits++
+	return nil
+}
+
+func (t *tx) Rollback() error {
+	t.count.mu.Lock()
+	defer t.count.mu.Unlock()
+	t.count.rollbacks++
+	return nil
+}
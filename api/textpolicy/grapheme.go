@@ -0,0 +1,92 @@
+package textpolicy
+
+import (
+	"unicode"
+)
+
+const zeroWidthJoiner = '\u200d'
+
+// 書記素クラスタ
+type cluster struct {
+	runes int
+	// 結合文字 (Mn, Me, Mc) の数
+	marks int
+}
+
+// 書記素クラスタに分割する
+//
+// UAX #29 の簡易的な実装で、以下を直前の文字と同じクラスタとして数える
+// - 結合文字 (Mn, Me, Mc)・異体字セレクタ・肌の色の修飾子・ZWJ
+// - 絵文字同士を繋ぐ ZWJ の次の絵文字 (ZWJ シーケンス)
+// - 国旗 (Regional Indicator の 2 文字)
+func segment(s string) []cluster {
+	var clusters []cluster
+	// 直前のクラスタが絵文字から始まり ZWJ で終わっている
+	joinPictographic := false
+	basePictographic := false
+	regionalIndicators := 0
+	for _, r := range s {
+		last := len(clusters) - 1
+		switch {
+		case last < 0:
+		case joinPictographic && isPictographic(r):
+			clusters[last].runes++
+			joinPictographic = false
+			continue
+		case r == zeroWidthJoiner:
+			clusters[last].runes++
+			joinPictographic = basePictographic
+			continue
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+			clusters[last].runes++
+			clusters[last].marks++
+			joinPictographic = false
+			continue
+		case unicode.Is(unicode.Variation_Selector, r), isEmojiModifier(r):
+			clusters[last].runes++
+			joinPictographic = false
+			continue
+		case isRegionalIndicator(r) && regionalIndicators%2 == 1:
+			clusters[last].runes++
+			regionalIndicators++
+			continue
+		}
+
+		clusters = append(clusters, cluster{runes: 1})
+		joinPictographic = false
+		basePictographic = isPictographic(r)
+		if isRegionalIndicator(r) {
+			regionalIndicators = 1
+		} else {
+			regionalIndicators = 0
+		}
+	}
+	return clusters
+}
+
+// 書記素クラスタの数を返す
+func GraphemeLength(s string) int {
+	return len(segment(s))
+}
+
+// Extended_Pictographic の簡易的な判定 (主な絵文字のブロック)
+func isPictographic(r rune) bool {
+	switch {
+	case 0x1F000 <= r && r <= 0x1FAFF,
+		0x2600 <= r && r <= 0x27BF,
+		0x2300 <= r && r <= 0x23FF,
+		0x2B00 <= r && r <= 0x2BFF,
+		0x2190 <= r && r <= 0x21FF,
+		r == 0x00A9, r == 0x00AE, r == 0x203C, r == 0x2049, r == 0x2122, r == 0x2139:
+		return true
+	}
+	return false
+}
+
+func isEmojiModifier(r rune) bool {
+	return 0x1F3FB <= r && r <= 0x1F3FF
+}
+
+func isRegionalIndicator(r rune) bool {
+	return 0x1F1E6 <= r && r <= 0x1F1FF
+}
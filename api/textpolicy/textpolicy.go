@@ -0,0 +1,188 @@
+// ユーザー名・チャットなど、ユーザーが入力して他のユーザーに表示する文字列の検証
+package textpolicy
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	CodeTooShort           = "too_short"
+	CodeTooLong            = "too_long"
+	CodeForbiddenCharacter = "forbidden_character"
+	CodeNGWord             = "ng_word"
+)
+
+const (
+	// 1 つの書記素クラスタに含められる結合文字の数 (Zalgo テキストなどの表示崩れを防ぐ)
+	maxMarksPerCluster = 3
+	// 1 つの書記素クラスタに含められる文字 (rune) の数 (長い ZWJ シーケンスを防ぐ)
+	maxRunesPerCluster = 16
+)
+
+// 検証に失敗した理由
+type Violation struct {
+	Code    string
+	Message string
+}
+
+type Policy struct {
+	// 長さは書記素クラスタ (見た目の 1 文字) で数える
+	MinLength int
+	MaxLength int
+	// 正規化後の文字 (rune) 数の上限 (保存先の DB のカラムの長さ、0 の場合は制限しない)
+	// NFKC は文字列を長くすることがあるため、正規化前の検証とは別に確認する
+	MaxRunes int
+	// 改行 (LF) を許可する
+	AllowNewline bool
+	// fold 済みの NG ワード
+	ngWords []string
+}
+
+// ngWords は表記揺れ (全角・半角、カタカナ・ひらがな、大文字・小文字) を区別せずに検出する
+func New(minLength, maxLength, maxRunes int, allowNewline bool, ngWords []string) *Policy {
+	p := &Policy{
+		MinLength:    minLength,
+		MaxLength:    maxLength,
+		MaxRunes:     maxRunes,
+		AllowNewline: allowNewline,
+	}
+	for _, w := range ngWords {
+		if folded := fold(w); folded != "" {
+			p.ngWords = append(p.ngWords, folded)
+		}
+	}
+	return p
+}
+
+// NFKC で正規化し、前後の空白を取り除く
+func Normalize(s string) string {
+	return strings.TrimSpace(norm.NFKC.String(s))
+}
+
+// s を正規化した文字列と、検証に失敗した理由を返す (問題が無い場合は空)
+func (p *Policy) Check(s string) (string, []Violation) {
+	normalized := Normalize(s)
+	var violations []Violation
+
+	if !utf8.ValidString(normalized) {
+		return normalized, []Violation{{
+			Code:    CodeForbiddenCharacter,
+			Message: "contains invalid UTF-8",
+		}}
+	}
+
+	clusters := segment(normalized)
+	length := len(clusters)
+	if length < p.MinLength {
+		violations = append(violations, Violation{
+			Code:    CodeTooShort,
+			Message: fmt.Sprintf("must be at least %d characters", p.MinLength),
+		})
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		violations = append(violations, Violation{
+			Code:    CodeTooLong,
+			Message: fmt.Sprintf("must be at most %d characters", p.MaxLength),
+		})
+	} else if p.MaxRunes > 0 && utf8.RuneCountInString(normalized) > p.MaxRunes {
+		violations = append(violations, Violation{
+			Code:    CodeTooLong,
+			Message: fmt.Sprintf("must be at most %d code points after normalization", p.MaxRunes),
+		})
+	}
+
+	for _, r := range normalized {
+		if p.isForbidden(r) {
+			violations = append(violations, Violation{
+				Code:    CodeForbiddenCharacter,
+				Message: fmt.Sprintf("contains forbidden character %U", r),
+			})
+			break
+		}
+	}
+
+	for _, c := range clusters {
+		if c.marks > maxMarksPerCluster || c.runes > maxRunesPerCluster {
+			violations = append(violations, Violation{
+				Code:    CodeForbiddenCharacter,
+				Message: "contains too many combining characters",
+			})
+			break
+		}
+	}
+
+	folded := fold(normalized)
+	for _, w := range p.ngWords {
+		if strings.Contains(folded, w) {
+			// どの単語に該当したかは返さない
+			violations = append(violations, Violation{
+				Code:    CodeNGWord,
+				Message: "contains a forbidden word",
+			})
+			break
+		}
+	}
+
+	return normalized, violations
+}
+
+// 制御文字・書式文字 (ZWJ を除く)・私用領域・未割り当ての文字、空白以外の区切り文字を許可しない
+func (p *Policy) isForbidden(r rune) bool {
+	switch {
+	case r == '\n':
+		return !p.AllowNewline
+	case r == ' ', r == zeroWidthJoiner:
+		return false
+	case unicode.Is(unicode.Cc, r),
+		unicode.Is(unicode.Cf, r),
+		unicode.Is(unicode.Co, r),
+		unicode.In(r, unicode.Zl, unicode.Zp, unicode.Zs):
+		return true
+	}
+	// 未割り当て (Cn)
+	return !unicode.In(r, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S)
+}
+
+// NG ワードの照合用に表記揺れを吸収する
+//
+// - NFKC (全角英数字・半角カナの統一)、小文字化、カタカナをひらがなに変換する
+// - 空白・記号を取り除く ("ば か" や "b.a.k.a" も検出する)
+func fold(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(norm.NFKC.String(s)) {
+		switch {
+		case 'ァ' <= r && r <= 'ヶ':
+			b.WriteRune(r - ('ァ' - 'ぁ'))
+		case r == 'ー':
+			// 長音記号は記号ではない (Lm) ため個別に残す
+			b.WriteRune(r)
+		case unicode.In(r, unicode.Z, unicode.P, unicode.S, unicode.Cc, unicode.Cf):
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// 1 行に 1 単語の NG ワードの辞書を読み込む (空行と # から始まる行は無視する)
+func LoadWords(path string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("LoadWords: %w", err)
+	}
+	var words []string
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+	return words, nil
+}
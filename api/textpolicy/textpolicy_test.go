@@ -0,0 +1,175 @@
+package textpolicy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGraphemeLength(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		s    string
+		want int
+	}{
+		"ascii":             {s: "abc", want: 3},
+		"japanese":          {s: "ひらがな漢字", want: 6},
+		"combining":         {s: "e\u0301", want: 1},
+		"skin_tone":         {s: "\U0001F44D\U0001F3FD", want: 1},
+		"zwj_sequence":      {s: "\U0001F468\u200d\U0001F469\u200d\U0001F467", want: 1},
+		"variation":         {s: "❤\ufe0f", want: 1},
+		"flags":             {s: "\U0001F1EF\U0001F1F5\U0001F1FA\U0001F1F8", want: 2},
+		"flag_and_ascii":    {s: "\U0001F1EF\U0001F1F5a", want: 2},
+		"odd_regional_pair": {s: "\U0001F1EF\U0001F1F5\U0001F1FA", want: 2},
+		"zwj_fire_heart":    {s: "❤\ufe0f\u200d\U0001F525", want: 1},
+		// ZWJ は絵文字同士のみを繋ぐ
+		"zwj_non_pictographic": {s: strings.Repeat("a\u200d", 3) + "a", want: 4},
+		"zwj_trailing":         {s: "\U0001F468\u200da", want: 2},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+			if got := GraphemeLength(tt.s); got != tt.want {
+				t.Errorf("want %d, but got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPolicyCheck(t *testing.T) {
+	t.Parallel()
+
+	p := New(1, 8, 64, false, []string{"バカ", "NG"})
+	chat := New(1, 140, 255, true, nil)
+
+	codes := func(vs []Violation) []string {
+		var c []string
+		for _, v := range vs {
+			c = append(c, v.Code)
+		}
+		return c
+	}
+
+	tests := map[string]struct {
+		policy         *Policy
+		s              string
+		wantNormalized string
+		wantCodes      []string
+	}{
+		"ok": {
+			policy:         p,
+			s:              "たろう",
+			wantNormalized: "たろう",
+		},
+		"ok_nfkc": {
+			// 全角英数字・半角カナ・全角スペースを正規化する
+			policy:         p,
+			s:              "　ＡＢｶﾞ１　",
+			wantNormalized: "ABガ1",
+		},
+		"ok_newline_in_chat": {
+			policy:         chat,
+			s:              "よろしく\nおねがいします",
+			wantNormalized: "よろしく\nおねがいします",
+		},
+		"ng_empty_after_trim": {
+			policy:         p,
+			s:              " 　 ",
+			wantNormalized: "",
+			wantCodes:      []string{CodeTooShort},
+		},
+		"ng_too_long": {
+			policy:         p,
+			s:              "123456789",
+			wantNormalized: "123456789",
+			wantCodes:      []string{CodeTooLong},
+		},
+		"ng_newline_in_name": {
+			policy:         p,
+			s:              "a\nb",
+			wantNormalized: "a\nb",
+			wantCodes:      []string{CodeForbiddenCharacter},
+		},
+		"ng_bidi_override": {
+			policy:         p,
+			s:              "a\u202eb",
+			wantNormalized: "a\u202eb",
+			wantCodes:      []string{CodeForbiddenCharacter},
+		},
+		"ng_private_use": {
+			policy:         p,
+			s:              "a\ue000",
+			wantNormalized: "a\ue000",
+			wantCodes:      []string{CodeForbiddenCharacter},
+		},
+		"ng_word_hiragana": {
+			policy:         p,
+			s:              "ばかです",
+			wantNormalized: "ばかです",
+			wantCodes:      []string{CodeNGWord},
+		},
+		"ng_word_halfwidth_kana": {
+			policy:         p,
+			s:              "ﾊﾞｶ",
+			wantNormalized: "バカ",
+			wantCodes:      []string{CodeNGWord},
+		},
+		"ng_word_separated": {
+			policy:         p,
+			s:              "ｎ．ｇ",
+			wantNormalized: "n.g",
+			wantCodes:      []string{CodeNGWord},
+		},
+		// NFKC は結合文字 30 個毎に CGJ (U+034F) を挿入する
+		"ng_zalgo": {
+			policy:         p,
+			s:              "a" + strings.Repeat("\u0301", 500),
+			wantNormalized: Normalize("a" + strings.Repeat("\u0301", 500)),
+			wantCodes:      []string{CodeTooLong, CodeForbiddenCharacter},
+		},
+		"ng_zwj_chain": {
+			policy:         p,
+			s:              strings.Repeat("a\u200d", 300) + "a",
+			wantNormalized: strings.Repeat("a\u200d", 300) + "a",
+			wantCodes:      []string{CodeTooLong},
+		},
+		"ng_long_emoji_zwj_sequence": {
+			policy:         p,
+			s:              strings.Repeat("\U0001F468\u200d", 20) + "\U0001F468",
+			wantNormalized: strings.Repeat("\U0001F468\u200d", 20) + "\U0001F468",
+			wantCodes:      []string{CodeForbiddenCharacter},
+		},
+		// U+FCF2 は NFKC で 3 文字になる (書記素クラスタの数は変わらない)
+		"ng_too_many_runes_after_nfkc": {
+			policy:         chat,
+			s:              strings.Repeat("\uFCF2", 140),
+			wantNormalized: strings.Repeat("\u0640\u064e\u0651", 140),
+			wantCodes:      []string{CodeTooLong},
+		},
+		"ng_multiple": {
+			policy:         p,
+			s:              "バカバカバカバカバカ",
+			wantNormalized: "バカバカバカバカバカ",
+			wantCodes:      []string{CodeTooLong, CodeNGWord},
+		},
+	}
+
+	for n, tt := range tests {
+		tt := tt
+		t.Run(n, func(t *testing.T) {
+			t.Parallel()
+
+			got, violations := tt.policy.Check(tt.s)
+			if got != tt.wantNormalized {
+				t.Errorf("want normalized %q, but got %q", tt.wantNormalized, got)
+			}
+			if d := cmp.Diff(codes(violations), tt.wantCodes); len(d) != 0 {
+				t.Errorf("differs: (-got +want)\n%s", d)
+			}
+		})
+	}
+}
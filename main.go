@@ -9,6 +9,7 @@ import (
 
 	"github.com/pollenjp/gameserver-go/api"
 	"github.com/pollenjp/gameserver-go/api/config"
+	"golang.org/x/sync/errgroup"
 )
 
 func main() {
@@ -36,12 +37,30 @@ func run(ctx context.Context) error {
 
 	// run server
 
-	mux, cleanup, err := api.NewMux(ctx, cfg)
+	mux, adminMux, cleanup, err := api.NewMuxWithAdmin(ctx, cfg)
 	defer cleanup()
 	if err != nil {
 		return err
 	}
 
 	s := api.NewServer(l, mux)
-	return s.Run(ctx)
+	if adminMux == nil {
+		return s.Run(ctx)
+	}
+
+	// 管理 API は別のポートで提供する (外部に公開しない想定)
+	al, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.AdminPort))
+	if err != nil {
+		log.Fatalf("failed to listen admin port %d : %v", cfg.AdminPort, err)
+	}
+	log.Printf("start admin api with http://%s", al.Addr().String())
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return s.Run(ctx)
+	})
+	eg.Go(func() error {
+		return api.NewServer(al, adminMux).Run(ctx)
+	})
+	return eg.Wait()
 }